@@ -0,0 +1,57 @@
+package buff
+
+import (
+	"time"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IncrementUsage records a redemption of a buff by a character, returning the updated daily use count
+func IncrementUsage(db *gorm.DB, log logrus.FieldLogger) func(tenantId uuid.UUID, characterId uint32, buffType string) model.Provider[uint32] {
+	return func(tenantId uuid.UUID, characterId uint32, buffType string) model.Provider[uint32] {
+		return func() (uint32, error) {
+			log.WithFields(logrus.Fields{
+				"characterId": characterId,
+				"buffType":    buffType,
+			}).Debug("Incrementing family buff usage")
+
+			now := time.Now()
+			entity := Entity{
+				TenantId:    tenantId,
+				CharacterId: characterId,
+				BuffType:    buffType,
+				Uses:        1,
+				UpdatedAt:   now,
+			}
+			err := db.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "tenant_id"}, {Name: "character_id"}, {Name: "buff_type"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"uses":       gorm.Expr("uses + 1"),
+					"updated_at": now,
+				}),
+			}).Create(&entity).Error
+			if err != nil {
+				return 0, err
+			}
+
+			return GetUsesProvider(tenantId, characterId, buffType)(db)()
+		}
+	}
+}
+
+// ResetDailyUsage clears the daily buff usage of every character
+func ResetDailyUsage(db *gorm.DB, log logrus.FieldLogger) model.Provider[int64] {
+	return func() (int64, error) {
+		log.Debug("Resetting daily family buff usage")
+
+		result := db.Where("uses > 0").Delete(&Entity{})
+		if result.Error != nil {
+			return 0, result.Error
+		}
+		return result.RowsAffected, nil
+	}
+}
@@ -0,0 +1,43 @@
+package buff
+
+// EntitlementBuilder constructs immutable catalog entitlements
+type EntitlementBuilder struct {
+	buffType   string
+	repCost    uint32
+	duration   uint32
+	dailyLimit uint32
+}
+
+// NewEntitlementBuilder creates a new entitlement builder with required parameters
+func NewEntitlementBuilder(buffType string, repCost uint32, duration uint32) *EntitlementBuilder {
+	return &EntitlementBuilder{
+		buffType: buffType,
+		repCost:  repCost,
+		duration: duration,
+	}
+}
+
+// Fluent setters for optional parameters
+
+func (b *EntitlementBuilder) SetDailyLimit(dailyLimit uint32) *EntitlementBuilder {
+	b.dailyLimit = dailyLimit
+	return b
+}
+
+// Build validates business rules and constructs the final immutable Entitlement
+func (b *EntitlementBuilder) Build() (Entitlement, error) {
+	if b.buffType == "" {
+		return Entitlement{}, ErrInvalidBuffType
+	}
+
+	if b.duration == 0 {
+		return Entitlement{}, ErrInvalidDuration
+	}
+
+	return Entitlement{
+		buffType:   b.buffType,
+		repCost:    b.repCost,
+		duration:   b.duration,
+		dailyLimit: b.dailyLimit,
+	}, nil
+}
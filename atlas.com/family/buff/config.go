@@ -0,0 +1,111 @@
+package buff
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// EnvCatalogFile configures the path of a JSON file describing the buff catalog per tenant
+const EnvCatalogFile = "FAMILY_BUFF_CATALOG_FILE"
+
+// EntitlementConfig is the file representation of a catalog entitlement
+type EntitlementConfig struct {
+	BuffType   string `json:"buffType"`
+	RepCost    uint32 `json:"repCost"`
+	Duration   uint32 `json:"duration"`
+	DailyLimit uint32 `json:"dailyLimit"`
+}
+
+// CatalogConfig holds the default catalog and per-tenant overrides keyed by tenant id
+type CatalogConfig struct {
+	Default []EntitlementConfig            `json:"default"`
+	Tenants map[string][]EntitlementConfig `json:"tenants"`
+}
+
+// DefaultCatalog is offered to every tenant without a configured catalog
+var DefaultCatalog = []EntitlementConfig{
+	{BuffType: "EXP_1_5X", RepCost: 800, Duration: 900, DailyLimit: 1},
+	{BuffType: "DROP_2X", RepCost: 700, Duration: 900, DailyLimit: 1},
+	{BuffType: "EXP_2X", RepCost: 1500, Duration: 900, DailyLimit: 1},
+	{BuffType: "EXP_DROP_2X", RepCost: 2000, Duration: 1800, DailyLimit: 1},
+}
+
+var (
+	catalogOnce   sync.Once
+	catalogConfig CatalogConfig
+)
+
+// Catalog returns the configured catalog, loading it from EnvCatalogFile on first use
+func Catalog() CatalogConfig {
+	catalogOnce.Do(func() {
+		catalogConfig = CatalogConfig{Default: DefaultCatalog}
+		if path, ok := os.LookupEnv(EnvCatalogFile); ok {
+			if data, err := os.ReadFile(path); err == nil {
+				if c, err := ParseCatalogConfig(data); err == nil {
+					catalogConfig = c
+				}
+			}
+		}
+	})
+	return catalogConfig
+}
+
+// ParseCatalogConfig parses and validates a JSON catalog, falling back to the default catalog when none is given
+func ParseCatalogConfig(data []byte) (CatalogConfig, error) {
+	var c CatalogConfig
+	if err := json.Unmarshal(data, &c); err != nil {
+		return CatalogConfig{}, err
+	}
+	if c.Default == nil {
+		c.Default = DefaultCatalog
+	}
+
+	if _, err := buildEntitlements(c.Default); err != nil {
+		return CatalogConfig{}, err
+	}
+	for _, ecs := range c.Tenants {
+		if _, err := buildEntitlements(ecs); err != nil {
+			return CatalogConfig{}, err
+		}
+	}
+	return c, nil
+}
+
+// Entitlements returns the catalog offered to the tenant
+func (c CatalogConfig) Entitlements(tenantId uuid.UUID) ([]Entitlement, error) {
+	if ecs, ok := c.Tenants[tenantId.String()]; ok {
+		return buildEntitlements(ecs)
+	}
+	return buildEntitlements(c.Default)
+}
+
+// Lookup returns the tenant's entitlement for the buff type
+func (c CatalogConfig) Lookup(tenantId uuid.UUID, buffType string) (Entitlement, error) {
+	es, err := c.Entitlements(tenantId)
+	if err != nil {
+		return Entitlement{}, err
+	}
+	for _, e := range es {
+		if e.BuffType() == buffType {
+			return e, nil
+		}
+	}
+	return Entitlement{}, ErrUnknownBuff
+}
+
+func buildEntitlements(ecs []EntitlementConfig) ([]Entitlement, error) {
+	es := make([]Entitlement, 0, len(ecs))
+	for _, ec := range ecs {
+		e, err := NewEntitlementBuilder(ec.BuffType, ec.RepCost, ec.Duration).
+			SetDailyLimit(ec.DailyLimit).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+	}
+	return es, nil
+}
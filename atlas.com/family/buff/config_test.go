@@ -0,0 +1,79 @@
+package buff
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseCatalogConfig(t *testing.T) {
+	tenantId := uuid.New()
+	data := []byte(`{
+		"default": [{"buffType": "EXP_1_5X", "repCost": 800, "duration": 900, "dailyLimit": 1}],
+		"tenants": {"` + tenantId.String() + `": [{"buffType": "DROP_2X", "repCost": 500, "duration": 600}]}
+	}`)
+
+	c, err := ParseCatalogConfig(data)
+	if err != nil {
+		t.Fatalf("Failed to parse catalog: %v", err)
+	}
+
+	e, err := c.Lookup(tenantId, "DROP_2X")
+	if err != nil {
+		t.Fatalf("Expected tenant entitlement, got error: %v", err)
+	}
+	if e.RepCost() != 500 || e.Duration() != 600 || e.DailyLimit() != 0 {
+		t.Errorf("Unexpected tenant entitlement: cost %d, duration %d, limit %d", e.RepCost(), e.Duration(), e.DailyLimit())
+	}
+
+	if _, err := c.Lookup(tenantId, "EXP_1_5X"); !errors.Is(err, ErrUnknownBuff) {
+		t.Errorf("Tenant catalog should replace the default catalog, got %v", err)
+	}
+
+	if _, err := c.Lookup(uuid.New(), "EXP_1_5X"); err != nil {
+		t.Errorf("Other tenants should use the default catalog, got %v", err)
+	}
+}
+
+func TestParseCatalogConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"Malformed JSON", `{"default": [`},
+		{"Missing buff type", `{"default": [{"repCost": 100, "duration": 60}]}`},
+		{"Zero duration", `{"default": [{"buffType": "EXP_2X", "repCost": 100}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCatalogConfig([]byte(tt.data)); err == nil {
+				t.Errorf("Expected error for %s", tt.data)
+			}
+		})
+	}
+}
+
+func TestUsage_CanRedeem(t *testing.T) {
+	limited, _ := NewEntitlementBuilder("EXP_2X", 100, 60).SetDailyLimit(2).Build()
+	unlimited, _ := NewEntitlementBuilder("DROP_2X", 100, 60).Build()
+
+	tests := []struct {
+		name      string
+		usage     Usage
+		canRedeem bool
+	}{
+		{"Under limit", NewUsage(1, limited, 1), true},
+		{"At limit", NewUsage(1, limited, 2), false},
+		{"Unlimited", NewUsage(1, unlimited, 100), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.usage.CanRedeem() != tt.canRedeem {
+				t.Errorf("CanRedeem() = %v, want %v", tt.usage.CanRedeem(), tt.canRedeem)
+			}
+		})
+	}
+}
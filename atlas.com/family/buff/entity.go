@@ -0,0 +1,27 @@
+package buff
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Entity represents the GORM-compatible database representation of a character's daily usage of a buff
+type Entity struct {
+	TenantId    uuid.UUID `gorm:"type:uuid;primaryKey" json:"tenantId"`
+	CharacterId uint32    `gorm:"primaryKey" json:"characterId"`
+	BuffType    string    `gorm:"primaryKey" json:"buffType"`
+	Uses        uint32    `gorm:"not null;default:0" json:"uses"`
+	UpdatedAt   time.Time `gorm:"not null" json:"updatedAt"`
+}
+
+// TableName specifies the table name for the Entity
+func (Entity) TableName() string {
+	return "family_buff_usage"
+}
+
+// Migration creates the family_buff_usage table
+func Migration(db *gorm.DB) error {
+	return db.AutoMigrate(&Entity{})
+}
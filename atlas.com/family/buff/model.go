@@ -0,0 +1,86 @@
+package buff
+
+import (
+	"errors"
+)
+
+// Entitlement represents an immutable catalog entry which can be redeemed for reputation
+type Entitlement struct {
+	buffType   string
+	repCost    uint32
+	duration   uint32
+	dailyLimit uint32
+}
+
+// Accessor methods for Entitlement
+func (e Entitlement) BuffType() string {
+	return e.buffType
+}
+
+func (e Entitlement) RepCost() uint32 {
+	return e.repCost
+}
+
+// Duration returns the buff duration in seconds
+func (e Entitlement) Duration() uint32 {
+	return e.duration
+}
+
+// DailyLimit returns the number of redemptions allowed per day, zero meaning unlimited
+func (e Entitlement) DailyLimit() uint32 {
+	return e.dailyLimit
+}
+
+// Usage represents an entitlement together with how often a character redeemed it today
+type Usage struct {
+	characterId uint32
+	entitlement Entitlement
+	usesToday   uint32
+}
+
+// Accessor methods for Usage
+func (u Usage) CharacterId() uint32 {
+	return u.characterId
+}
+
+func (u Usage) Entitlement() Entitlement {
+	return u.entitlement
+}
+
+func (u Usage) UsesToday() uint32 {
+	return u.usesToday
+}
+
+// Business logic methods
+
+// CanRedeem returns true if the daily limit of the entitlement has not been reached
+func (u Usage) CanRedeem() bool {
+	return u.entitlement.dailyLimit == 0 || u.usesToday < u.entitlement.dailyLimit
+}
+
+// RemainingUses returns how many redemptions remain today, or nil when unlimited
+func (u Usage) RemainingUses() *uint32 {
+	if u.entitlement.dailyLimit == 0 {
+		return nil
+	}
+	remaining := uint32(0)
+	if u.usesToday < u.entitlement.dailyLimit {
+		remaining = u.entitlement.dailyLimit - u.usesToday
+	}
+	return &remaining
+}
+
+// NewUsage creates a usage for the character and entitlement
+func NewUsage(characterId uint32, entitlement Entitlement, usesToday uint32) Usage {
+	return Usage{
+		characterId: characterId,
+		entitlement: entitlement,
+		usesToday:   usesToday,
+	}
+}
+
+// Validation errors
+var (
+	ErrInvalidBuffType = errors.New("invalid buff type")
+	ErrInvalidDuration = errors.New("buff duration must be positive")
+)
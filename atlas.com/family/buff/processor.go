@@ -0,0 +1,175 @@
+package buff
+
+import (
+	"context"
+	"errors"
+
+	"atlas-family/family"
+	"atlas-family/idempotency"
+	"atlas-family/kafka/message"
+	familymsg "atlas-family/kafka/message/family"
+
+	"github.com/Chronicle20/atlas-model/model"
+	tenant "github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Processor interface defines the buff redemption operations
+type Processor interface {
+	WithTransaction(db *gorm.DB) Processor
+	Redeem(buf *message.Buffer) func(characterId uint32, buffType string) model.Provider[Usage]
+
+	// AndEmit variants for Kafka message emission
+	RedeemAndEmit(transactionId uuid.UUID, characterId uint32, buffType string) model.Provider[Usage]
+
+	GetUsage(characterId uint32) ([]Usage, error)
+}
+
+// ProcessorImpl implements the Processor interface
+type ProcessorImpl struct {
+	log     logrus.FieldLogger
+	ctx     context.Context
+	db      *gorm.DB
+	t       tenant.Model
+	catalog CatalogConfig
+
+	// transactionId attributes the rep deducted for a redemption in the reputation ledger
+	transactionId uuid.UUID
+}
+
+// NewProcessor creates a new processor instance
+func NewProcessor(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) Processor {
+	return &ProcessorImpl{
+		log:     l,
+		ctx:     ctx,
+		db:      db,
+		t:       tenant.MustFromContext(ctx),
+		catalog: Catalog(),
+	}
+}
+
+// Business logic errors
+var (
+	ErrUnknownBuff       = errors.New("buff is not offered in the family catalog")
+	ErrDailyLimitReached = errors.New("daily redemption limit reached for buff")
+)
+
+func (p *ProcessorImpl) WithTransaction(db *gorm.DB) Processor {
+	return &ProcessorImpl{
+		log:           p.log,
+		ctx:           p.ctx,
+		db:            db,
+		t:             p.t,
+		catalog:       p.catalog,
+		transactionId: p.transactionId,
+	}
+}
+
+func (p *ProcessorImpl) withTransactionId(transactionId uuid.UUID) *ProcessorImpl {
+	tp := p.WithTransaction(p.db).(*ProcessorImpl)
+	tp.transactionId = transactionId
+	return tp
+}
+
+// Redeem spends a character's reputation on a catalog entitlement, enforcing its daily usage limit
+func (p *ProcessorImpl) Redeem(buf *message.Buffer) func(characterId uint32, buffType string) model.Provider[Usage] {
+	return func(characterId uint32, buffType string) model.Provider[Usage] {
+		return func() (Usage, error) {
+			p.log.WithFields(logrus.Fields{
+				"characterId": characterId,
+				"buffType":    buffType,
+			}).Info("Redeeming family buff")
+
+			e, err := p.catalog.Lookup(p.t.Id(), buffType)
+			if err != nil {
+				if errors.Is(err, ErrUnknownBuff) {
+					p.putError(buf, 0, characterId, "UNKNOWN_BUFF", err, 0)
+				}
+				return Usage{}, err
+			}
+
+			var result Usage
+			var member family.FamilyMember
+			err = p.db.Transaction(func(tx *gorm.DB) error {
+				uses, err := GetUsesProvider(p.t.Id(), characterId, buffType)(tx)()
+				if err != nil {
+					return err
+				}
+				if !NewUsage(characterId, e, uses).CanRedeem() {
+					return ErrDailyLimitReached
+				}
+
+				// Deduct the cost, which buffers the redeemed rep event or an insufficient rep error
+				member, err = family.NewProcessor(p.log, p.ctx, tx).WithTransactionId(p.transactionId).DeductRep(buf)(characterId, e.RepCost(), buffType)()
+				if err != nil {
+					return err
+				}
+
+				uses, err = IncrementUsage(tx, p.log)(p.t.Id(), characterId, buffType)()
+				if err != nil {
+					return err
+				}
+				result = NewUsage(characterId, e, uses)
+				return nil
+			})
+			if err != nil {
+				if errors.Is(err, ErrDailyLimitReached) {
+					p.putError(buf, 0, characterId, "BUFF_DAILY_LIMIT_REACHED", err, e.RepCost())
+				}
+				return Usage{}, err
+			}
+
+			if buf != nil {
+				if putErr := buf.Put(familymsg.EnvEventTopicRep, RedeemedEventProvider(member.World(), characterId, e)); putErr != nil {
+					p.log.WithError(putErr).Error("Failed to add buff redeemed event to buffer")
+				}
+			}
+			return result, nil
+		}
+	}
+}
+
+func (p *ProcessorImpl) putError(buf *message.Buffer, worldId byte, characterId uint32, errorCode string, err error, amount uint32) {
+	if buf == nil {
+		return
+	}
+	if putErr := buf.Put(familymsg.EnvEventTopicErrors, family.RepErrorEventProvider(worldId, characterId, errorCode, err.Error(), amount)); putErr != nil {
+		p.log.WithError(putErr).Error("Failed to add rep error event to buffer")
+	}
+}
+
+// AndEmit variants - combine business logic with event emission. Each runs at most once per transaction ID.
+
+// RedeemAndEmit redeems a buff and emits appropriate events
+func (p *ProcessorImpl) RedeemAndEmit(transactionId uuid.UUID, characterId uint32, buffType string) model.Provider[Usage] {
+	return func() (Usage, error) {
+		return idempotency.EmitOnce[Usage](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeRedeemBuff)(func(tx *gorm.DB, buf *message.Buffer) (Usage, error) {
+			return p.WithTransaction(tx).(*ProcessorImpl).withTransactionId(transactionId).Redeem(buf)(characterId, buffType)()
+		})
+	}
+}
+
+// GetUsage returns the tenant's catalog annotated with the character's usage today
+func (p *ProcessorImpl) GetUsage(characterId uint32) ([]Usage, error) {
+	es, err := p.catalog.Entitlements(p.t.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := GetUsageProvider(p.t.Id(), characterId)(p.db)()
+	if err != nil {
+		return nil, err
+	}
+	uses := make(map[string]uint32, len(entities))
+	for _, entity := range entities {
+		uses[entity.BuffType] = entity.Uses
+	}
+
+	results := make([]Usage, 0, len(es))
+	for _, e := range es {
+		results = append(results, NewUsage(characterId, e, uses[e.BuffType()]))
+	}
+	return results, nil
+}
@@ -0,0 +1,16 @@
+package buff
+
+import (
+	"atlas-family/kafka/message/family"
+
+	"github.com/Chronicle20/atlas-kafka/producer"
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/segmentio/kafka-go"
+)
+
+// RedeemedEventProvider creates a Kafka message provider for buff redeemed events
+func RedeemedEventProvider(worldId byte, characterId uint32, e Entitlement) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := family.NewBuffRedeemedEvent(worldId, characterId, e.BuffType(), e.RepCost(), e.Duration())
+	return producer.SingleMessageProvider(key, value)
+}
@@ -0,0 +1,36 @@
+package buff
+
+import (
+	"atlas-family/database"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetUsageProvider retrieves every buff usage recorded today for a character within a tenant
+func GetUsageProvider(tenantId uuid.UUID, characterId uint32) database.EntityProvider[[]Entity] {
+	return func(db *gorm.DB) model.Provider[[]Entity] {
+		var entities []Entity
+		if err := db.Where("tenant_id = ? AND character_id = ?", tenantId, characterId).Find(&entities).Error; err != nil {
+			return model.ErrorProvider[[]Entity](err)
+		}
+		return model.FixedProvider(entities)
+	}
+}
+
+// GetUsesProvider retrieves how often a character redeemed a buff today within a tenant
+func GetUsesProvider(tenantId uuid.UUID, characterId uint32, buffType string) database.EntityProvider[uint32] {
+	return func(db *gorm.DB) model.Provider[uint32] {
+		var entities []Entity
+		if err := db.Where("tenant_id = ? AND character_id = ? AND buff_type = ?", tenantId, characterId, buffType).
+			Limit(1).
+			Find(&entities).Error; err != nil {
+			return model.ErrorProvider[uint32](err)
+		}
+		if len(entities) == 0 {
+			return model.FixedProvider(uint32(0))
+		}
+		return model.FixedProvider(entities[0].Uses)
+	}
+}
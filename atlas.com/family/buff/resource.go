@@ -0,0 +1,93 @@
+package buff
+
+import (
+	"atlas-family/database"
+	"atlas-family/family"
+	"atlas-family/rest"
+	"errors"
+	"net/http"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-rest/server"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/jtumidanski/api2go/jsonapi"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// InitResource registers all buff-related REST endpoints
+func InitResource(si jsonapi.ServerInformation) func(db *gorm.DB) server.RouteInitializer {
+	return func(db *gorm.DB) server.RouteInitializer {
+		return func(router *mux.Router, l logrus.FieldLogger) {
+			router.HandleFunc("/families/{characterId}/buffs", rest.RegisterHandler(l)(si)("get_buffs", getBuffsHandler(db))).Methods(http.MethodGet)
+			router.HandleFunc("/families/{characterId}/buffs", rest.RegisterInputHandler[RedeemRequest](l)(si)("redeem_buff", redeemBuffHandler(db))).Methods(http.MethodPost)
+		}
+	}
+}
+
+// getBuffsHandler handles GET /families/{characterId}/buffs
+func getBuffsHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				usage, err := NewProcessor(d.Logger(), d.Context(), db).GetUsage(characterId)
+				if err != nil {
+					d.Logger().WithError(err).Error("Failed to get family buffs")
+					rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+					return
+				}
+
+				rms, err := model.SliceMap(Transform)(model.FixedProvider(usage))(model.ParallelMap())()
+				if err != nil {
+					d.Logger().WithError(err).Error("Failed to transform family buffs to REST model")
+					rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+					return
+				}
+
+				query := r.URL.Query()
+				queryParams := jsonapi.ParseQueryFields(&query)
+				server.MarshalResponse[[]RestModel](d.Logger())(w)(c.ServerInformation())(queryParams)(rms)
+			}
+		})
+	}
+}
+
+// redeemBuffHandler handles POST /families/{characterId}/buffs
+func redeemBuffHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext, input RedeemRequest) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext, input RedeemRequest) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if input.BuffType == "" {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, "Buff type is required")
+					return
+				}
+
+				result, err := NewProcessor(d.Logger(), d.Context(), db).RedeemAndEmit(uuid.New(), characterId, input.BuffType)()
+				if err != nil {
+					d.Logger().WithError(err).Error("Failed to redeem family buff")
+					switch {
+					case errors.Is(err, ErrUnknownBuff), errors.Is(err, family.ErrMemberNotFound):
+						rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+					case errors.Is(err, ErrDailyLimitReached), errors.Is(err, family.ErrInsufficientRep), errors.Is(err, database.ErrConflict):
+						rest.WriteErrorResponse(w, http.StatusConflict, err.Error())
+					default:
+						rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+					}
+					return
+				}
+
+				rm, err := Transform(result)
+				if err != nil {
+					d.Logger().WithError(err).Error("Failed to transform family buff to REST model")
+					rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+					return
+				}
+
+				query := r.URL.Query()
+				queryParams := jsonapi.ParseQueryFields(&query)
+				server.MarshalResponse[RestModel](d.Logger())(w)(c.ServerInformation())(queryParams)(rm)
+			}
+		})
+	}
+}
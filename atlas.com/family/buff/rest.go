@@ -0,0 +1,58 @@
+package buff
+
+// RestModel represents a catalog entitlement and a character's usage of it in REST/JSON:API format
+type RestModel struct {
+	Id            string  `json:"-"`
+	CharacterId   uint32  `json:"characterId"`
+	RepCost       uint32  `json:"repCost"`
+	Duration      uint32  `json:"duration"`
+	DailyLimit    uint32  `json:"dailyLimit"`
+	UsesToday     uint32  `json:"usesToday"`
+	RemainingUses *uint32 `json:"remainingUses,omitempty"`
+}
+
+// GetName returns the resource type for JSON:API compatibility
+func (r RestModel) GetName() string {
+	return "familyBuffs"
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r RestModel) GetID() string {
+	return r.Id
+}
+
+// Transform converts a domain usage to REST representation
+func Transform(u Usage) (RestModel, error) {
+	return RestModel{
+		Id:            u.Entitlement().BuffType(),
+		CharacterId:   u.CharacterId(),
+		RepCost:       u.Entitlement().RepCost(),
+		Duration:      u.Entitlement().Duration(),
+		DailyLimit:    u.Entitlement().DailyLimit(),
+		UsesToday:     u.UsesToday(),
+		RemainingUses: u.RemainingUses(),
+	}, nil
+}
+
+// RedeemRequest represents the request body for redeeming a buff
+type RedeemRequest struct {
+	BuffType string `json:"buffType"`
+}
+
+// GetName returns the resource type for JSON:API compatibility
+func (r RedeemRequest) GetName() string {
+	return "familyBuffs"
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r RedeemRequest) GetID() string {
+	return r.BuffType
+}
+
+// SetID accepts the buff type as the resource ID when the client supplies one
+func (r *RedeemRequest) SetID(id string) error {
+	if id != "" {
+		r.BuffType = id
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrConflict is returned when a row changed between being read and being conditionally updated
+var ErrConflict = errors.New("row was modified concurrently")
+
+// EnvConflictRetries configures how many times an operation is re-run after losing an optimistic concurrency check
+const EnvConflictRetries = "DB_CONFLICT_RETRIES"
+
+// DefaultConflictRetries is used when no retry limit is configured
+const DefaultConflictRetries = 3
+
+// ConflictRetries returns the configured number of retries after a conflict
+func ConflictRetries() int {
+	if value, ok := os.LookupEnv(EnvConflictRetries); ok {
+		if retries, err := strconv.Atoi(value); err == nil && retries >= 0 {
+			return retries
+		}
+	}
+	return DefaultConflictRetries
+}
+
+// RetryOnConflict re-runs an operation which failed with ErrConflict up to the given number of times, backing off
+// briefly between attempts. The operation must roll back its own changes when it fails.
+func RetryOnConflict(l logrus.FieldLogger, retries int) func(f func() error) error {
+	return func(f func() error) error {
+		var err error
+		for attempt := 0; ; attempt++ {
+			err = f()
+			if !errors.Is(err, ErrConflict) || attempt >= retries {
+				return err
+			}
+			l.WithError(err).WithField("attempt", attempt+1).Debug("Retrying operation after concurrent modification")
+			time.Sleep(time.Duration(attempt+1) * 10 * time.Millisecond)
+		}
+	}
+}
@@ -37,7 +37,7 @@ func CreateMember(db *gorm.DB, log logrus.FieldLogger) func(characterId uint32,
 		}).Info("Creating new family member")
 
 		// Check if member already exists
-		exists, err := ExistsProvider(characterId)(db)()
+		exists, err := ExistsProvider(tenantId, characterId)(db)()
 		if err != nil {
 			return model.ErrorProvider[Entity](err)
 		}
@@ -56,60 +56,82 @@ func CreateMember(db *gorm.DB, log logrus.FieldLogger) func(characterId uint32,
 	}
 }
 
-// BatchResetDailyRep resets daily reputation for all members
-func BatchResetDailyRep(db *gorm.DB, log logrus.FieldLogger) model.Provider[BatchResetResult] {
-	return func() (BatchResetResult, error) {
-		log.Info("Performing batch daily reputation reset")
+// BatchResetDailyRep resets daily reputation for all members of a tenant
+func BatchResetDailyRep(db *gorm.DB, log logrus.FieldLogger) func(tenantId uuid.UUID) model.Provider[BatchResetResult] {
+	return func(tenantId uuid.UUID) model.Provider[BatchResetResult] {
+		return func() (BatchResetResult, error) {
+			log.WithField("tenantId", tenantId).Info("Performing batch daily reputation reset")
 
-		resetTime := time.Now()
+			resetTime := time.Now()
 
-		// Reset daily rep for all members
-		result := db.Model(&Entity{}).
-			Where("daily_rep > 0").
-			Updates(map[string]interface{}{
-				"daily_rep":  0,
-				"updated_at": time.Now(),
-			})
+			// Reset daily rep for all members of the tenant
+			result := db.Model(&Entity{}).
+				Where("tenant_id = ? AND daily_rep > 0", tenantId).
+				Updates(map[string]interface{}{
+					"daily_rep":  0,
+					"version":    gorm.Expr("version + 1"),
+					"updated_at": time.Now(),
+				})
 
-		if result.Error != nil {
-			return BatchResetResult{}, result.Error
-		}
-		affectedCount := result.RowsAffected
+			if result.Error != nil {
+				return BatchResetResult{}, result.Error
+			}
+			affectedCount := result.RowsAffected
 
-		return BatchResetResult{
-			AffectedCount: affectedCount,
-			ResetTime:     resetTime,
-		}, nil
+			return BatchResetResult{
+				AffectedCount: affectedCount,
+				ResetTime:     resetTime,
+			}, nil
+		}
 	}
 }
 
-// SaveMember saves a family member to the database (create or update)
+// SaveMember saves a family member to the database (create or update). An update only applies if the stored row is
+// still at the version the member was loaded at, failing with a ConflictError otherwise. The returned entity carries
+// the new version.
 func SaveMember(db *gorm.DB, log logrus.FieldLogger) func(member FamilyMember) model.Provider[Entity] {
 	return func(member FamilyMember) model.Provider[Entity] {
 		log.WithFields(logrus.Fields{
 			"characterId": member.CharacterId(),
 			"id":          member.Id(),
+			"version":     member.Version(),
 		}).Debug("Saving family member to database")
 
 		entity := ToEntity(member)
 
-		// Use Save which handles both create and update
-		if err := db.Save(&entity).Error; err != nil {
-			return model.ErrorProvider[Entity](err)
+		if entity.ID == 0 {
+			if err := db.Create(&entity).Error; err != nil {
+				return model.ErrorProvider[Entity](err)
+			}
+			return model.FixedProvider(entity)
+		}
+
+		entity.Version = member.Version() + 1
+		result := db.Model(&entity).
+			Where("tenant_id = ? AND version = ?", member.TenantId(), member.Version()).
+			Select("*").
+			Omit("created_at").
+			Updates(&entity)
+		if result.Error != nil {
+			return model.ErrorProvider[Entity](result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return model.ErrorProvider[Entity](ConflictError{CharacterId: member.CharacterId(), Version: member.Version()})
 		}
 		return model.FixedProvider(entity)
 	}
 }
 
 // DeleteMember deletes a family member from the database
-func DeleteMember(db *gorm.DB, log logrus.FieldLogger) func(characterId uint32) model.Provider[bool] {
-	return func(characterId uint32) model.Provider[bool] {
+func DeleteMember(db *gorm.DB, log logrus.FieldLogger) func(tenantId uuid.UUID, characterId uint32) model.Provider[bool] {
+	return func(tenantId uuid.UUID, characterId uint32) model.Provider[bool] {
 		return func() (bool, error) {
 			log.WithFields(logrus.Fields{
+				"tenantId":    tenantId,
 				"characterId": characterId,
 			}).Debug("Deleting family member from database")
 
-			result := db.Where("character_id = ?", characterId).Delete(&Entity{})
+			result := db.Where("tenant_id = ? AND character_id = ?", tenantId, characterId).Delete(&Entity{})
 			if result.Error != nil {
 				return false, result.Error
 			}
@@ -118,3 +140,99 @@ func DeleteMember(db *gorm.DB, log logrus.FieldLogger) func(characterId uint32)
 		}
 	}
 }
+
+// SyncFamily reconciles the family aggregate led by a root ancestor with the members currently linked beneath it.
+// Families led by former roots now inside the tree are dissolved, and a lone root is left without a family.
+func SyncFamily(db *gorm.DB, log logrus.FieldLogger) func(tenantId uuid.UUID, rootId uint32) model.Provider[uint32] {
+	return func(tenantId uuid.UUID, rootId uint32) model.Provider[uint32] {
+		return func() (uint32, error) {
+			entities, err := GetSubtreeProvider(tenantId, rootId)(db)()
+			if err != nil {
+				if errors.Is(err, ErrMemberNotFound) {
+					return 0, db.Where("tenant_id = ? AND leader_id = ?", tenantId, rootId).Delete(&FamilyEntity{}).Error
+				}
+				return 0, err
+			}
+
+			memberIds := make([]uint32, 0, len(entities))
+			for _, e := range entities {
+				memberIds = append(memberIds, e.CharacterId)
+			}
+
+			// A merged tree keeps only the family of its root
+			if len(memberIds) > 1 {
+				if err := db.Where("tenant_id = ? AND leader_id IN ?", tenantId, memberIds[1:]).Delete(&FamilyEntity{}).Error; err != nil {
+					return 0, err
+				}
+			}
+
+			if len(memberIds) <= 1 {
+				log.WithField("rootId", rootId).Debug("Dissolving family without linked members")
+				if err := db.Where("tenant_id = ? AND leader_id = ?", tenantId, rootId).Delete(&FamilyEntity{}).Error; err != nil {
+					return 0, err
+				}
+				return 0, db.Model(&Entity{}).Where("tenant_id = ? AND character_id = ? AND family_id IS NOT NULL", tenantId, rootId).Updates(map[string]interface{}{
+					"family_id": nil,
+					"version":   gorm.Expr("version + 1"),
+				}).Error
+			}
+
+			familyEntity, err := GetFamilyByLeaderIdProvider(tenantId, rootId)(db)()
+			if err != nil && !errors.Is(err, ErrFamilyNotFound) {
+				return 0, err
+			}
+			if errors.Is(err, ErrFamilyNotFound) {
+				familyEntity = FamilyEntity{
+					TenantId:  tenantId,
+					LeaderId:  rootId,
+					CreatedAt: time.Now(),
+				}
+			}
+			familyEntity.MemberCount = uint32(len(memberIds))
+			familyEntity.UpdatedAt = time.Now()
+
+			log.WithFields(logrus.Fields{
+				"rootId":      rootId,
+				"familyId":    familyEntity.ID,
+				"memberCount": familyEntity.MemberCount,
+			}).Debug("Synchronizing family membership")
+
+			if err := db.Save(&familyEntity).Error; err != nil {
+				return 0, err
+			}
+			if err := db.Model(&Entity{}).Where("tenant_id = ? AND character_id IN ? AND (family_id IS NULL OR family_id <> ?)", tenantId, memberIds, familyEntity.ID).Updates(map[string]interface{}{
+				"family_id": familyEntity.ID,
+				"version":   gorm.Expr("version + 1"),
+			}).Error; err != nil {
+				return 0, err
+			}
+			return familyEntity.ID, nil
+		}
+	}
+}
+
+// UpdateFamily persists the leader editable details of a family aggregate
+func UpdateFamily(db *gorm.DB, log logrus.FieldLogger) func(family Family) model.Provider[FamilyEntity] {
+	return func(family Family) model.Provider[FamilyEntity] {
+		log.WithFields(logrus.Fields{
+			"familyId": family.Id(),
+			"name":     family.Name(),
+		}).Debug("Updating family details")
+
+		result := db.Model(&FamilyEntity{}).
+			Where("tenant_id = ? AND id = ?", family.TenantId(), family.Id()).
+			Updates(map[string]interface{}{
+				"name":       family.Name(),
+				"precept":    family.Precept(),
+				"notice":     family.Notice(),
+				"updated_at": family.UpdatedAt(),
+			})
+		if result.Error != nil {
+			return model.ErrorProvider[FamilyEntity](result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return model.ErrorProvider[FamilyEntity](ErrFamilyNotFound)
+		}
+		return GetFamilyByIdProvider(family.TenantId(), family.Id())(db)
+	}
+}
@@ -3,10 +3,12 @@ package family
 import (
 	"time"
 
+	"atlas-family/rules"
+
 	"github.com/google/uuid"
 )
 
-// NewBuilder creates a new builder with required parameters
+// NewBuilder creates a new builder with required parameters, validated against the rules of the tenant
 func NewBuilder(characterId uint32, tenantId uuid.UUID, level uint16, world byte) *Builder {
 	return &Builder{
 		characterId: characterId,
@@ -16,6 +18,7 @@ func NewBuilder(characterId uint32, tenantId uuid.UUID, level uint16, world byte
 		juniorIds:   []uint32{},
 		createdAt:   time.Now(),
 		updatedAt:   time.Now(),
+		rules:       rules.For(tenantId),
 	}
 }
 
@@ -58,6 +61,39 @@ func (b *Builder) RemoveJunior(juniorId uint32) *Builder {
 	return b
 }
 
+func (b *Builder) SetVersion(version uint32) *Builder {
+	b.version = version
+	return b
+}
+
+func (b *Builder) SetFamilyId(familyId uint32) *Builder {
+	b.familyId = &familyId
+	return b
+}
+
+func (b *Builder) ClearFamilyId() *Builder {
+	b.familyId = nil
+	return b
+}
+
+func (b *Builder) SetCooldownUntil(cooldownUntil time.Time) *Builder {
+	b.cooldownUntil = &cooldownUntil
+	return b
+}
+
+// StartCooldown sets the cooldown to end cooldown after from, leaving it unchanged when the cooldown is zero
+func (b *Builder) StartCooldown(from time.Time, cooldown time.Duration) *Builder {
+	if cooldown <= 0 {
+		return b
+	}
+	return b.SetCooldownUntil(from.Add(cooldown))
+}
+
+func (b *Builder) ClearCooldown() *Builder {
+	b.cooldownUntil = nil
+	return b
+}
+
 func (b *Builder) SetRep(rep uint32) *Builder {
 	b.rep = rep
 	return b
@@ -92,6 +128,11 @@ func (b *Builder) ResetDailyRep() *Builder {
 	return b
 }
 
+func (b *Builder) SetPendingKills(pendingKills uint32) *Builder {
+	b.pendingKills = pendingKills
+	return b
+}
+
 func (b *Builder) SetLevel(level uint16) *Builder {
 	b.level = level
 	return b
@@ -102,7 +143,6 @@ func (b *Builder) SetWorld(world byte) *Builder {
 	return b
 }
 
-
 func (b *Builder) SetCreatedAt(createdAt time.Time) *Builder {
 	b.createdAt = createdAt
 	return b
@@ -113,6 +153,11 @@ func (b *Builder) SetUpdatedAt(updatedAt time.Time) *Builder {
 	return b
 }
 
+func (b *Builder) SetRules(r rules.Model) *Builder {
+	b.rules = r
+	return b
+}
+
 func (b *Builder) Touch() *Builder {
 	b.updatedAt = time.Now()
 	return b
@@ -137,12 +182,17 @@ func (b *Builder) Build() (FamilyMember, error) {
 		return FamilyMember{}, err
 	}
 
+	// A member left above a lowered limit keeps their juniors, but may not gain more
+	if len(b.juniorIds) > b.loadedJuniors && uint32(len(b.juniorIds)) > b.rules.MaxJuniors() {
+		return FamilyMember{}, ErrTooManyJuniors
+	}
+
 	if err := ValidateSeniorId(b.characterId, b.seniorId); err != nil {
 		return FamilyMember{}, err
 	}
 
 	// Business rule validations
-	if b.dailyRep > 5000 {
+	if b.dailyRep > b.rules.DailyRepCap() {
 		return FamilyMember{}, ErrInvalidDailyRep
 	}
 
@@ -151,16 +201,111 @@ func (b *Builder) Build() (FamilyMember, error) {
 	copy(juniorIds, b.juniorIds)
 
 	return FamilyMember{
+		id:            b.id,
+		characterId:   b.characterId,
+		tenantId:      b.tenantId,
+		seniorId:      b.seniorId,
+		juniorIds:     juniorIds,
+		familyId:      b.familyId,
+		rep:           b.rep,
+		dailyRep:      b.dailyRep,
+		pendingKills:  b.pendingKills,
+		level:         b.level,
+		world:         b.world,
+		version:       b.version,
+		cooldownUntil: b.cooldownUntil,
+		createdAt:     b.createdAt,
+		updatedAt:     b.updatedAt,
+		rules:         b.rules,
+	}, nil
+}
+
+// FamilyBuilder constructs immutable Family aggregates
+type FamilyBuilder struct {
+	id          uint32
+	tenantId    uuid.UUID
+	name        string
+	leaderId    uint32
+	memberCount uint32
+	precept     string
+	notice      string
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// NewFamilyBuilder creates a new family builder with required parameters
+func NewFamilyBuilder(tenantId uuid.UUID, leaderId uint32) *FamilyBuilder {
+	return &FamilyBuilder{
+		tenantId:  tenantId,
+		leaderId:  leaderId,
+		createdAt: time.Now(),
+		updatedAt: time.Now(),
+	}
+}
+
+func (b *FamilyBuilder) SetId(id uint32) *FamilyBuilder {
+	b.id = id
+	return b
+}
+
+func (b *FamilyBuilder) SetName(name string) *FamilyBuilder {
+	b.name = name
+	return b
+}
+
+func (b *FamilyBuilder) SetMemberCount(memberCount uint32) *FamilyBuilder {
+	b.memberCount = memberCount
+	return b
+}
+
+func (b *FamilyBuilder) SetPrecept(precept string) *FamilyBuilder {
+	b.precept = precept
+	return b
+}
+
+func (b *FamilyBuilder) SetNotice(notice string) *FamilyBuilder {
+	b.notice = notice
+	return b
+}
+
+func (b *FamilyBuilder) SetCreatedAt(createdAt time.Time) *FamilyBuilder {
+	b.createdAt = createdAt
+	return b
+}
+
+func (b *FamilyBuilder) SetUpdatedAt(updatedAt time.Time) *FamilyBuilder {
+	b.updatedAt = updatedAt
+	return b
+}
+
+func (b *FamilyBuilder) Touch() *FamilyBuilder {
+	b.updatedAt = time.Now()
+	return b
+}
+
+// Build validates business rules and constructs the final immutable Family
+func (b *FamilyBuilder) Build() (Family, error) {
+	if err := ValidateCharacterId(b.leaderId); err != nil {
+		return Family{}, err
+	}
+
+	if err := ValidateTenantId(b.tenantId); err != nil {
+		return Family{}, err
+	}
+
+	if err := ValidateFamilyName(b.name); err != nil {
+		return Family{}, err
+	}
+
+	return Family{
 		id:          b.id,
-		characterId: b.characterId,
 		tenantId:    b.tenantId,
-		seniorId:    b.seniorId,
-		juniorIds:   juniorIds,
-		rep:         b.rep,
-		dailyRep:    b.dailyRep,
-		level:       b.level,
-		world:       b.world,
+		name:        b.name,
+		leaderId:    b.leaderId,
+		memberCount: b.memberCount,
+		precept:     b.precept,
+		notice:      b.notice,
 		createdAt:   b.createdAt,
 		updatedAt:   b.updatedAt,
 	}, nil
-}
\ No newline at end of file
+}
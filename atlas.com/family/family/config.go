@@ -0,0 +1,71 @@
+package family
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// EnvRepPropagationSplit configures the percentage of a junior's rep credited to each generation of seniors
+const EnvRepPropagationSplit = "REPUTATION_PROPAGATION_SPLIT"
+
+// DefaultRepPropagationSplit credits the full amount to the senior and half to the senior's senior
+var DefaultRepPropagationSplit = []uint32{100, 50}
+
+// RepPropagationSplit returns the configured per-generation split, falling back to the default
+func RepPropagationSplit() []uint32 {
+	if value, ok := os.LookupEnv(EnvRepPropagationSplit); ok {
+		if split, err := ParseRepPropagationSplit(value); err == nil {
+			return split
+		}
+	}
+	return append([]uint32{}, DefaultRepPropagationSplit...)
+}
+
+// ParseRepPropagationSplit parses a comma separated list of percentages, e.g. "100,50"
+func ParseRepPropagationSplit(value string) ([]uint32, error) {
+	parts := strings.Split(value, ",")
+	split := make([]uint32, 0, len(parts))
+	for _, part := range parts {
+		percentage, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		if percentage > 100 {
+			return nil, strconv.ErrRange
+		}
+		split = append(split, uint32(percentage))
+	}
+	return split, nil
+}
+
+// EnvPreceptMaxLength configures the longest family precept a leader may set
+const EnvPreceptMaxLength = "FAMILY_PRECEPT_MAX_LENGTH"
+
+// EnvNoticeMaxLength configures the longest family notice a leader may set
+const EnvNoticeMaxLength = "FAMILY_NOTICE_MAX_LENGTH"
+
+// Default text limits used when none are configured
+const (
+	DefaultPreceptMaxLength = uint32(200)
+	DefaultNoticeMaxLength  = uint32(200)
+)
+
+// PreceptMaxLength returns the configured precept limit in characters
+func PreceptMaxLength() uint32 {
+	return maxLength(EnvPreceptMaxLength, DefaultPreceptMaxLength)
+}
+
+// NoticeMaxLength returns the configured notice limit in characters
+func NoticeMaxLength() uint32 {
+	return maxLength(EnvNoticeMaxLength, DefaultNoticeMaxLength)
+}
+
+func maxLength(env string, fallback uint32) uint32 {
+	if value, ok := os.LookupEnv(env); ok {
+		if length, err := strconv.ParseUint(value, 10, 32); err == nil {
+			return uint32(length)
+		}
+	}
+	return fallback
+}
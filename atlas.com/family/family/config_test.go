@@ -0,0 +1,43 @@
+package family
+
+import (
+	"testing"
+)
+
+func TestParseRepPropagationSplit(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    []uint32
+		expectError bool
+	}{
+		{"Single generation", "100", []uint32{100}, false},
+		{"Two generations", "100,50", []uint32{100, 50}, false},
+		{"Whitespace tolerated", " 80 , 20 ", []uint32{80, 20}, false},
+		{"Not a number", "100,half", nil, true},
+		{"Over one hundred percent", "150", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			split, err := ParseRepPropagationSplit(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %q but got none", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+			if len(split) != len(tt.expected) {
+				t.Fatalf("Expected %d generations, got %d", len(tt.expected), len(split))
+			}
+			for i := range split {
+				if split[i] != tt.expected[i] {
+					t.Errorf("Generation %d: expected %d, got %d", i, tt.expected[i], split[i])
+				}
+			}
+		})
+	}
+}
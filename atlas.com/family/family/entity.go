@@ -1,25 +1,32 @@
 package family
 
 import (
+	"fmt"
 	"time"
 
+	"atlas-family/rules"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 // Entity represents the GORM-compatible database representation of a family member
 type Entity struct {
-	ID          uint32    `gorm:"primaryKey;autoIncrement" json:"id"`
-	CharacterId uint32    `gorm:"uniqueIndex;not null" json:"characterId"`
-	TenantId    uuid.UUID `gorm:"type:uuid;not null;index" json:"tenantId"`
-	SeniorId    *uint32   `gorm:"index" json:"seniorId"`
-	JuniorIds   []uint32  `gorm:"serializer:json" json:"juniorIds"`
-	Rep         uint32    `gorm:"default:0" json:"rep"`
-	DailyRep    uint32    `gorm:"default:0" json:"dailyRep"`
-	Level       uint16    `gorm:"not null" json:"level"`
-	World       byte      `gorm:"not null" json:"world"`
-	CreatedAt   time.Time `gorm:"not null" json:"createdAt"`
-	UpdatedAt   time.Time `gorm:"not null" json:"updatedAt"`
+	ID            uint32     `gorm:"primaryKey;autoIncrement" json:"id"`
+	CharacterId   uint32     `gorm:"not null;uniqueIndex:idx_family_members_tenant_character_unique,priority:2" json:"characterId"`
+	TenantId      uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_family_members_tenant_character_unique,priority:1" json:"tenantId"`
+	SeniorId      *uint32    `gorm:"index" json:"seniorId"`
+	JuniorIds     []uint32   `gorm:"serializer:json" json:"juniorIds"`
+	FamilyId      *uint32    `gorm:"index" json:"familyId"`
+	Rep           uint32     `gorm:"default:0" json:"rep"`
+	DailyRep      uint32     `gorm:"default:0" json:"dailyRep"`
+	PendingKills  uint32     `gorm:"default:0" json:"pendingKills"`
+	Level         uint16     `gorm:"not null" json:"level"`
+	World         byte       `gorm:"not null" json:"world"`
+	Version       uint32     `gorm:"not null;default:0" json:"version"`
+	CooldownUntil *time.Time `json:"cooldownUntil"`
+	CreatedAt     time.Time  `gorm:"not null" json:"createdAt"`
+	UpdatedAt     time.Time  `gorm:"not null" json:"updatedAt"`
 }
 
 // TableName specifies the table name for the Entity
@@ -29,6 +36,16 @@ func (Entity) TableName() string {
 
 // Migration creates the family_members table with proper indexes and constraints
 func Migration(db *gorm.DB) error {
+	// Character IDs are only unique within a tenant, so the original global unique index and the plain tenant index it
+	// was paired with give way to a unique (tenant_id, character_id) index
+	for _, name := range []string{"idx_family_members_character_id", "idx_family_members_tenant_character"} {
+		if db.Migrator().HasIndex(&Entity{}, name) {
+			if err := db.Migrator().DropIndex(&Entity{}, name); err != nil {
+				return err
+			}
+		}
+	}
+
 	err := db.AutoMigrate(&Entity{})
 	if err != nil {
 		return err
@@ -36,12 +53,9 @@ func Migration(db *gorm.DB) error {
 
 	// Get the database dialect name
 	dialectName := db.Dialector.Name()
-	
+
 	// Add indexes for optimized queries
 	err = db.Exec(`
-		CREATE INDEX IF NOT EXISTS idx_family_members_tenant_character 
-		ON family_members(tenant_id, character_id);
-		
 		CREATE INDEX IF NOT EXISTS idx_family_members_world 
 		ON family_members(world);
 		
@@ -70,19 +84,6 @@ func Migration(db *gorm.DB) error {
 
 	// Add constraints based on database type
 	if dialectName == "postgres" {
-		err = db.Exec(`
-			DO $$ BEGIN
-				IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'check_junior_count') THEN
-					ALTER TABLE family_members 
-					ADD CONSTRAINT check_junior_count 
-					CHECK (array_length(junior_ids, 1) IS NULL OR array_length(junior_ids, 1) <= 2);
-				END IF;
-			END $$;
-		`).Error
-		if err != nil {
-			return err
-		}
-		
 		// Add PostgreSQL-specific constraints
 		err = db.Exec(`
 			DO $$ BEGIN
@@ -92,9 +93,6 @@ func Migration(db *gorm.DB) error {
 				IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'check_daily_rep_non_negative') THEN
 					ALTER TABLE family_members ADD CONSTRAINT check_daily_rep_non_negative CHECK (daily_rep >= 0);
 				END IF;
-				IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'check_daily_rep_limit') THEN
-					ALTER TABLE family_members ADD CONSTRAINT check_daily_rep_limit CHECK (daily_rep <= 5000);
-				END IF;
 				IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'check_level_positive') THEN
 					ALTER TABLE family_members ADD CONSTRAINT check_level_positive CHECK (level > 0);
 				END IF;
@@ -112,7 +110,26 @@ func Migration(db *gorm.DB) error {
 		// This is acceptable for integration tests
 	}
 
-	return nil
+	return ApplyRuleConstraints(db)(rules.GetRegistry().Config().Ceiling())
+}
+
+// ApplyRuleConstraints replaces the junior count and daily rep constraints with the given limits. Constraints are
+// shared by every tenant, so they are given the most permissive rules across tenants; each tenant's own rules are
+// enforced by the model. Existing rows are not re-checked, so lowering a limit does not fail the migration. Junior IDs
+// are stored as JSON text, so they are counted as a JSON array and a null list always passes.
+func ApplyRuleConstraints(db *gorm.DB) func(ceiling rules.Model) error {
+	return func(ceiling rules.Model) error {
+		if db.Dialector.Name() != "postgres" {
+			return nil
+		}
+		return db.Exec(fmt.Sprintf(`
+			ALTER TABLE family_members DROP CONSTRAINT IF EXISTS check_junior_count;
+			ALTER TABLE family_members ADD CONSTRAINT check_junior_count
+				CHECK (CASE WHEN json_typeof(junior_ids::json) = 'array' THEN json_array_length(junior_ids::json) <= %d ELSE true END) NOT VALID;
+			ALTER TABLE family_members DROP CONSTRAINT IF EXISTS check_daily_rep_limit;
+			ALTER TABLE family_members ADD CONSTRAINT check_daily_rep_limit CHECK (daily_rep <= %d) NOT VALID;
+		`, ceiling.MaxJuniors(), ceiling.DailyRepCap())).Error
+	}
 }
 
 // Make transforms an Entity into an immutable FamilyMember model
@@ -130,6 +147,7 @@ func Make(entity Entity) (FamilyMember, error) {
 		return FamilyMember{}, err
 	}
 
+	r := rules.For(entity.TenantId)
 	if err := ValidateJuniorIds(entity.CharacterId, entity.JuniorIds); err != nil {
 		return FamilyMember{}, err
 	}
@@ -143,17 +161,95 @@ func Make(entity Entity) (FamilyMember, error) {
 	copy(juniorIds, entity.JuniorIds)
 
 	return FamilyMember{
-		id:          entity.ID,
-		characterId: entity.CharacterId,
-		tenantId:    entity.TenantId,
-		seniorId:    entity.SeniorId,
-		juniorIds:   juniorIds,
-		rep:         entity.Rep,
-		dailyRep:    entity.DailyRep,
-		level:       entity.Level,
-		world:       entity.World,
-		createdAt:   entity.CreatedAt,
-		updatedAt:   entity.UpdatedAt,
+		id:            entity.ID,
+		characterId:   entity.CharacterId,
+		tenantId:      entity.TenantId,
+		seniorId:      entity.SeniorId,
+		juniorIds:     juniorIds,
+		familyId:      entity.FamilyId,
+		rep:           entity.Rep,
+		dailyRep:      entity.DailyRep,
+		pendingKills:  entity.PendingKills,
+		level:         entity.Level,
+		world:         entity.World,
+		version:       entity.Version,
+		cooldownUntil: entity.CooldownUntil,
+		createdAt:     entity.CreatedAt,
+		updatedAt:     entity.UpdatedAt,
+		rules:         r,
+	}, nil
+}
+
+// FamilyEntity represents the GORM-compatible database representation of a family aggregate
+type FamilyEntity struct {
+	ID          uint32    `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantId    uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_families_tenant_leader,priority:1" json:"tenantId"`
+	Name        string    `gorm:"not null;default:''" json:"name"`
+	LeaderId    uint32    `gorm:"not null;uniqueIndex:idx_families_tenant_leader,priority:2" json:"leaderId"`
+	MemberCount uint32    `gorm:"not null;default:0" json:"memberCount"`
+	Precept     string    `gorm:"not null;default:''" json:"precept"`
+	Notice      string    `gorm:"not null;default:''" json:"notice"`
+	CreatedAt   time.Time `gorm:"not null" json:"createdAt"`
+	UpdatedAt   time.Time `gorm:"not null" json:"updatedAt"`
+}
+
+// TableName specifies the table name for the FamilyEntity
+func (FamilyEntity) TableName() string {
+	return "families"
+}
+
+// FamilyMigration creates the families table, replacing the original global unique leader index with a unique
+// (tenant_id, leader_id) index
+func FamilyMigration(db *gorm.DB) error {
+	if db.Migrator().HasIndex(&FamilyEntity{}, "idx_families_leader_id") {
+		if err := db.Migrator().DropIndex(&FamilyEntity{}, "idx_families_leader_id"); err != nil {
+			return err
+		}
+	}
+	return db.AutoMigrate(&FamilyEntity{})
+}
+
+// MakeFamily transforms a FamilyEntity into an immutable Family model
+func MakeFamily(entity FamilyEntity) (Family, error) {
+	return NewFamilyBuilder(entity.TenantId, entity.LeaderId).
+		SetId(entity.ID).
+		SetName(entity.Name).
+		SetMemberCount(entity.MemberCount).
+		SetPrecept(entity.Precept).
+		SetNotice(entity.Notice).
+		SetCreatedAt(entity.CreatedAt).
+		SetUpdatedAt(entity.UpdatedAt).
+		Build()
+}
+
+// PedigreeEntity is a family member row annotated with its depth below the pedigree root
+type PedigreeEntity struct {
+	Entity `gorm:"embedded"`
+	Depth  uint32 `gorm:"column:depth"`
+}
+
+// MakePedigree transforms the rows below a root ancestor into an immutable Pedigree
+func MakePedigree(rootId uint32, entities []PedigreeEntity) (Pedigree, error) {
+	nodes := make([]PedigreeNode, 0, len(entities))
+	for _, entity := range entities {
+		member, err := Make(entity.Entity)
+		if err != nil {
+			return Pedigree{}, err
+		}
+
+		var parentId *uint32
+		if entity.CharacterId != rootId {
+			parentId = member.SeniorId()
+		}
+		nodes = append(nodes, PedigreeNode{
+			member:   member,
+			depth:    entity.Depth,
+			parentId: parentId,
+		})
+	}
+	return Pedigree{
+		rootId: rootId,
+		nodes:  nodes,
 	}, nil
 }
 
@@ -164,16 +260,20 @@ func ToEntity(fm FamilyMember) Entity {
 	copy(juniorIds, fm.juniorIds)
 
 	return Entity{
-		ID:          fm.id,
-		CharacterId: fm.characterId,
-		TenantId:    fm.tenantId,
-		SeniorId:    fm.seniorId,
-		JuniorIds:   juniorIds,
-		Rep:         fm.rep,
-		DailyRep:    fm.dailyRep,
-		Level:       fm.level,
-		World:       fm.world,
-		CreatedAt:   fm.createdAt,
-		UpdatedAt:   fm.updatedAt,
+		ID:            fm.id,
+		CharacterId:   fm.characterId,
+		TenantId:      fm.tenantId,
+		SeniorId:      fm.seniorId,
+		JuniorIds:     juniorIds,
+		FamilyId:      fm.familyId,
+		Rep:           fm.rep,
+		DailyRep:      fm.dailyRep,
+		PendingKills:  fm.pendingKills,
+		Level:         fm.level,
+		World:         fm.world,
+		Version:       fm.version,
+		CooldownUntil: fm.cooldownUntil,
+		CreatedAt:     fm.createdAt,
+		UpdatedAt:     fm.updatedAt,
 	}
-}
\ No newline at end of file
+}
@@ -2,26 +2,74 @@ package family
 
 import (
 	"errors"
+	"math"
+	"strings"
 	"time"
+	"unicode/utf8"
+
+	"atlas-family/rules"
 
 	"github.com/google/uuid"
 )
 
 // FamilyMember represents an immutable family member with private fields
 type FamilyMember struct {
-	tenantId    uuid.UUID
-	id          uint32
-	characterId uint32
-	seniorId    *uint32
-	juniorIds   []uint32
-	rep         uint32
-	dailyRep    uint32
-	level       uint16
-	world       byte
-	createdAt   time.Time
-	updatedAt   time.Time
+	tenantId      uuid.UUID
+	id            uint32
+	characterId   uint32
+	seniorId      *uint32
+	juniorIds     []uint32
+	familyId      *uint32
+	rep           uint32
+	dailyRep      uint32
+	pendingKills  uint32
+	level         uint16
+	world         byte
+	version       uint32
+	cooldownUntil *time.Time
+	createdAt     time.Time
+	updatedAt     time.Time
+	rules         rules.Model
 }
 
+// Activity types accepted for reputation conversion
+const (
+	ActivityTypeMobKill    = "mob_kill"
+	ActivityTypeExpedition = "expedition"
+)
+
+// Activity conversion rules
+const (
+	KillsPerRepAward        = 5
+	RepPerKillAward         = 2
+	ExpeditionRepMultiplier = 10
+)
+
+// RepPenaltyReasonJuniorOutlevelsSenior is reported when rep is halved because the junior outlevels the senior
+const RepPenaltyReasonJuniorOutlevelsSenior = "JUNIOR_OUTLEVELS_SENIOR"
+
+// LinkBrokenReason is the structured reason reported when family links are broken
+type LinkBrokenReason string
+
+const (
+	// ReasonBreakLink is reported when a member breaks all of their links at once
+	ReasonBreakLink LinkBrokenReason = "BREAK_LINK"
+	// ReasonLeftSenior is reported when a junior leaves their senior
+	ReasonLeftSenior LinkBrokenReason = "LEFT_SENIOR"
+	// ReasonExpelled is reported when a senior expels a junior
+	ReasonExpelled LinkBrokenReason = "EXPELLED"
+	// ReasonFamilyDissolved is reported for every link broken when a leader dissolves their family
+	ReasonFamilyDissolved LinkBrokenReason = "FAMILY_DISSOLVED"
+	// ReasonCharacterDeleted is reported when a member leaves the family because their character was deleted
+	ReasonCharacterDeleted LinkBrokenReason = "CHARACTER_DELETED"
+)
+
+// MaxFamilyNameLength is the longest name a leader may give their family
+const MaxFamilyNameLength = 12
+
+// MaxPedigreeDepth bounds how many generations are walked when resolving a pedigree
+const MaxPedigreeDepth = 64
+
 // Accessor methods for FamilyMember
 func (fm FamilyMember) Id() uint32 {
 	return fm.id
@@ -46,6 +94,11 @@ func (fm FamilyMember) JuniorIds() []uint32 {
 	return result
 }
 
+// FamilyId returns the family aggregate the member belongs to, or nil when unlinked
+func (fm FamilyMember) FamilyId() *uint32 {
+	return fm.familyId
+}
+
 func (fm FamilyMember) Rep() uint32 {
 	return fm.rep
 }
@@ -54,6 +107,11 @@ func (fm FamilyMember) DailyRep() uint32 {
 	return fm.dailyRep
 }
 
+// PendingKills returns the kills carried over towards the next rep award
+func (fm FamilyMember) PendingKills() uint32 {
+	return fm.pendingKills
+}
+
 func (fm FamilyMember) Level() uint16 {
 	return fm.level
 }
@@ -62,6 +120,11 @@ func (fm FamilyMember) World() byte {
 	return fm.world
 }
 
+// Version returns the optimistic concurrency version the member was loaded at
+func (fm FamilyMember) Version() uint32 {
+	return fm.version
+}
+
 func (fm FamilyMember) CreatedAt() time.Time {
 	return fm.createdAt
 }
@@ -70,6 +133,22 @@ func (fm FamilyMember) UpdatedAt() time.Time {
 	return fm.updatedAt
 }
 
+// CooldownUntil returns when the member may join a family again after leaving or being expelled, or nil when they
+// never left
+func (fm FamilyMember) CooldownUntil() *time.Time {
+	return fm.cooldownUntil
+}
+
+// IsOnCooldown returns true if the member may not join a family at the given time
+func (fm FamilyMember) IsOnCooldown(now time.Time) bool {
+	return fm.cooldownUntil != nil && now.Before(*fm.cooldownUntil)
+}
+
+// Rules returns the family rules of the member's tenant
+func (fm FamilyMember) Rules() rules.Model {
+	return fm.rules
+}
+
 // Business logic methods
 
 // HasSenior returns true if the member has a senior
@@ -89,7 +168,7 @@ func (fm FamilyMember) JuniorCount() int {
 
 // CanAddJunior returns true if the member can add another junior
 func (fm FamilyMember) CanAddJunior() bool {
-	return len(fm.juniorIds) < 2
+	return fm.rules.CanAddJunior(len(fm.juniorIds))
 }
 
 // HasJunior returns true if the specified character is a junior
@@ -104,11 +183,7 @@ func (fm FamilyMember) HasJunior(characterId uint32) bool {
 
 // ValidateLevelDifference checks if the level difference is within acceptable range
 func (fm FamilyMember) ValidateLevelDifference(otherLevel uint16) bool {
-	diff := int(fm.level) - int(otherLevel)
-	if diff < 0 {
-		diff = -diff
-	}
-	return diff <= 20
+	return fm.rules.WithinLevelDifference(fm.level, otherLevel)
 }
 
 // IsSameWorld returns true if the member is on the same world
@@ -118,43 +193,180 @@ func (fm FamilyMember) IsSameWorld(world byte) bool {
 
 // IsRepCapReached returns true if daily rep limit is reached
 func (fm FamilyMember) IsRepCapReached() bool {
-	return fm.dailyRep >= 5000
+	return fm.rules.RemainingDailyRep(fm.dailyRep) == 0
+}
+
+// RemainingDailyRep returns how much more rep the member can receive today
+func (fm FamilyMember) RemainingDailyRep() uint32 {
+	return fm.rules.RemainingDailyRep(fm.dailyRep)
 }
 
 // CanReceiveRep returns true if the member can receive more rep today
 func (fm FamilyMember) CanReceiveRep(amount uint32) bool {
-	return fm.dailyRep+amount <= 5000
+	return fm.rules.CanReceiveRep(fm.dailyRep, amount)
 }
 
-// Builder forward declaration - implementation in builder.go
-type Builder struct {
+// PedigreeNode represents a member's position within a multi-generation pedigree
+type PedigreeNode struct {
+	member   FamilyMember
+	depth    uint32
+	parentId *uint32
+}
+
+// Accessor methods for PedigreeNode
+func (n PedigreeNode) Member() FamilyMember {
+	return n.member
+}
+
+// Depth returns the number of generations between the node and the pedigree root
+func (n PedigreeNode) Depth() uint32 {
+	return n.depth
+}
+
+// ParentId returns the character ID of the node's senior within the pedigree, or nil for the root
+func (n PedigreeNode) ParentId() *uint32 {
+	return n.parentId
+}
+
+// Pedigree represents every descendant of a root ancestor, ordered by depth
+type Pedigree struct {
+	rootId uint32
+	nodes  []PedigreeNode
+}
+
+// Accessor methods for Pedigree
+func (p Pedigree) RootId() uint32 {
+	return p.rootId
+}
+
+func (p Pedigree) Nodes() []PedigreeNode {
+	return append([]PedigreeNode{}, p.nodes...)
+}
+
+// Depth returns the deepest generation present in the pedigree
+func (p Pedigree) Depth() uint32 {
+	depth := uint32(0)
+	for _, n := range p.nodes {
+		if n.depth > depth {
+			depth = n.depth
+		}
+	}
+	return depth
+}
+
+// Family represents an immutable named family led by the root ancestor of its members
+type Family struct {
 	id          uint32
-	characterId uint32
 	tenantId    uuid.UUID
-	seniorId    *uint32
-	juniorIds   []uint32
-	rep         uint32
-	dailyRep    uint32
-	level       uint16
-	world       byte
+	name        string
+	leaderId    uint32
+	memberCount uint32
+	precept     string
+	notice      string
 	createdAt   time.Time
 	updatedAt   time.Time
 }
 
+// Accessor methods for Family
+func (f Family) Id() uint32 {
+	return f.id
+}
+
+func (f Family) TenantId() uuid.UUID {
+	return f.tenantId
+}
+
+func (f Family) Name() string {
+	return f.name
+}
+
+func (f Family) LeaderId() uint32 {
+	return f.leaderId
+}
+
+func (f Family) MemberCount() uint32 {
+	return f.memberCount
+}
+
+// Precept returns the family motto shown to all members
+func (f Family) Precept() string {
+	return f.precept
+}
+
+// Notice returns the leader's notice shown to all members
+func (f Family) Notice() string {
+	return f.notice
+}
+
+func (f Family) CreatedAt() time.Time {
+	return f.createdAt
+}
+
+func (f Family) UpdatedAt() time.Time {
+	return f.updatedAt
+}
+
+// IsLeader returns true if the character leads the family
+func (f Family) IsLeader(characterId uint32) bool {
+	return f.leaderId == characterId
+}
+
+// Builder returns a new builder for modification
+func (f Family) Builder() *FamilyBuilder {
+	return &FamilyBuilder{
+		id:          f.id,
+		tenantId:    f.tenantId,
+		name:        f.name,
+		leaderId:    f.leaderId,
+		memberCount: f.memberCount,
+		precept:     f.precept,
+		notice:      f.notice,
+		createdAt:   f.createdAt,
+		updatedAt:   f.updatedAt,
+	}
+}
+
+// Builder forward declaration - implementation in builder.go
+type Builder struct {
+	id            uint32
+	characterId   uint32
+	tenantId      uuid.UUID
+	seniorId      *uint32
+	juniorIds     []uint32
+	familyId      *uint32
+	rep           uint32
+	dailyRep      uint32
+	pendingKills  uint32
+	level         uint16
+	world         byte
+	version       uint32
+	cooldownUntil *time.Time
+	createdAt     time.Time
+	updatedAt     time.Time
+	rules         rules.Model
+	loadedJuniors int
+}
+
 // Builder returns a new builder for modification
 func (fm FamilyMember) Builder() *Builder {
 	return &Builder{
-		id:          fm.id,
-		characterId: fm.characterId,
-		tenantId:    fm.tenantId,
-		seniorId:    fm.seniorId,
-		juniorIds:   append([]uint32{}, fm.juniorIds...),
-		rep:         fm.rep,
-		dailyRep:    fm.dailyRep,
-		level:       fm.level,
-		world:       fm.world,
-		createdAt:   fm.createdAt,
-		updatedAt:   fm.updatedAt,
+		id:            fm.id,
+		characterId:   fm.characterId,
+		tenantId:      fm.tenantId,
+		seniorId:      fm.seniorId,
+		juniorIds:     append([]uint32{}, fm.juniorIds...),
+		familyId:      fm.familyId,
+		rep:           fm.rep,
+		dailyRep:      fm.dailyRep,
+		pendingKills:  fm.pendingKills,
+		level:         fm.level,
+		world:         fm.world,
+		version:       fm.version,
+		cooldownUntil: fm.cooldownUntil,
+		createdAt:     fm.createdAt,
+		updatedAt:     fm.updatedAt,
+		rules:         fm.rules,
+		loadedJuniors: len(fm.juniorIds),
 	}
 }
 
@@ -163,10 +375,12 @@ var (
 	ErrInvalidCharacterId = errors.New("invalid character ID")
 	ErrInvalidTenantId    = errors.New("invalid tenant ID")
 	ErrInvalidLevel       = errors.New("invalid level")
-	ErrTooManyJuniors     = errors.New("cannot have more than 2 juniors")
+	ErrTooManyJuniors     = errors.New("cannot have more juniors than the family rules allow")
 	ErrSelfReference      = errors.New("cannot reference self as senior or junior")
 	ErrDuplicateJunior    = errors.New("duplicate junior ID")
-	ErrInvalidDailyRep    = errors.New("daily rep cannot exceed 5000")
+	ErrInvalidDailyRep    = errors.New("daily rep cannot exceed the daily rep cap")
+	ErrInvalidFamilyName  = errors.New("invalid family name")
+	ErrFamilyTextTooLong  = errors.New("family text exceeds maximum length")
 )
 
 // Pure functions for business logic validation
@@ -195,12 +409,9 @@ func ValidateLevel(level uint16) error {
 	return nil
 }
 
-// ValidateJuniorIds validates junior IDs list
+// ValidateJuniorIds validates junior IDs list. The tenant's junior limit is only enforced when a junior is added, so a
+// member keeps loading after the limit is lowered.
 func ValidateJuniorIds(characterId uint32, juniorIds []uint32) error {
-	if len(juniorIds) > 2 {
-		return ErrTooManyJuniors
-	}
-
 	// Check for self-reference
 	for _, juniorId := range juniorIds {
 		if juniorId == characterId {
@@ -228,13 +439,25 @@ func ValidateSeniorId(characterId uint32, seniorId *uint32) error {
 	return nil
 }
 
-// ValidateLevelDifference validates the level difference between senior and junior (package-level)
-func ValidateLevelDifference(seniorLevel uint16, juniorLevel uint16) bool {
-	diff := int(seniorLevel) - int(juniorLevel)
-	if diff < 0 {
-		diff = -diff
+// ValidateFamilyName validates a family name, allowing an empty name for families not yet named
+func ValidateFamilyName(name string) error {
+	if utf8.RuneCountInString(name) > MaxFamilyNameLength || strings.TrimSpace(name) != name {
+		return ErrInvalidFamilyName
 	}
-	return diff <= 20
+	return nil
+}
+
+// ValidateFamilyText validates a precept or notice against the configured maximum length in characters
+func ValidateFamilyText(text string, maxLength uint32) error {
+	if uint32(utf8.RuneCountInString(text)) > maxLength {
+		return ErrFamilyTextTooLong
+	}
+	return nil
+}
+
+// ValidateLevelDifference validates the level difference between senior and junior under the default rules
+func ValidateLevelDifference(seniorLevel uint16, juniorLevel uint16) bool {
+	return rules.Default().WithinLevelDifference(seniorLevel, juniorLevel)
 }
 
 // ValidateLocation validates that senior and junior are on the same world and map
@@ -242,7 +465,53 @@ func ValidateLocation(seniorWorld byte, seniorMap uint32, juniorWorld byte, juni
 	return seniorWorld == juniorWorld && seniorMap == juniorMap
 }
 
-// ValidateDailyRepCap validates that adding additional rep doesn't exceed the daily cap
+// WouldCreateCycle returns true if linking the junior under a senior with the given ancestry would make the junior its own ancestor
+func WouldCreateCycle(seniorId uint32, seniorAncestry []uint32, juniorId uint32) bool {
+	if seniorId == juniorId {
+		return true
+	}
+	for _, ancestorId := range seniorAncestry {
+		if ancestorId == juniorId {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateDailyRepCap validates that adding additional rep doesn't exceed the daily cap of the default rules
 func ValidateDailyRepCap(currentDailyRep uint32, additionalRep uint32) bool {
-	return currentDailyRep+additionalRep <= 5000
+	return rules.Default().CanReceiveRep(currentDailyRep, additionalRep)
+}
+
+// CalculateKillRep converts kills into rep at 2 rep per 5 kills, returning the rep earned and the kills carried over
+func CalculateKillRep(pendingKills uint32, killCount uint32) (uint32, uint32) {
+	total := uint64(pendingKills) + uint64(killCount)
+	return clampRep((total / KillsPerRepAward) * RepPerKillAward), uint32(total % KillsPerRepAward)
+}
+
+// CalculateExpeditionRep converts an expedition coin reward into rep
+func CalculateExpeditionRep(coinReward uint32) uint32 {
+	return clampRep(uint64(coinReward) * ExpeditionRepMultiplier)
+}
+
+// clampRep narrows a rep amount computed in 64 bits, clamping it to the largest amount a member can hold
+func clampRep(amount uint64) uint32 {
+	if amount > math.MaxUint32 {
+		return math.MaxUint32
+	}
+	return uint32(amount)
+}
+
+// RepShare returns the given percentage of amount, clamped to the largest amount a member can hold
+func RepShare(amount uint32, share uint32) uint32 {
+	return clampRep(uint64(amount) * uint64(share) / 100)
+}
+
+// ApplyLevelPenalty halves rep when the junior outlevels the senior, returning the credited and lost amounts
+func ApplyLevelPenalty(juniorLevel uint16, seniorLevel uint16, amount uint32) (uint32, uint32) {
+	if juniorLevel <= seniorLevel {
+		return amount, 0
+	}
+	credited := amount / 2
+	return credited, amount - credited
 }
@@ -1,6 +1,7 @@
 package family
 
 import (
+	"math"
 	"testing"
 
 	"github.com/google/uuid"
@@ -201,6 +202,126 @@ func TestValidateDailyRepCap(t *testing.T) {
 	}
 }
 
+func TestCalculateKillRep(t *testing.T) {
+	tests := []struct {
+		name          string
+		pendingKills  uint32
+		killCount     uint32
+		expectedRep   uint32
+		expectedCarry uint32
+	}{
+		{"Below threshold", 0, 4, 0, 4},
+		{"Exactly one award", 0, 5, 2, 0},
+		{"Multiple awards with remainder", 0, 12, 4, 2},
+		{"Carry completes an award", 3, 2, 2, 0},
+		{"Carry plus remainder", 4, 7, 4, 1},
+		{"Total beyond uint32 does not wrap", 4, math.MaxUint32, 1717986918, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rep, carry := CalculateKillRep(tt.pendingKills, tt.killCount)
+			if rep != tt.expectedRep || carry != tt.expectedCarry {
+				t.Errorf("CalculateKillRep(%d, %d) = (%d, %d), want (%d, %d)",
+					tt.pendingKills, tt.killCount, rep, carry, tt.expectedRep, tt.expectedCarry)
+			}
+		})
+	}
+}
+
+func TestCalculateExpeditionRep(t *testing.T) {
+	tests := []struct {
+		name       string
+		coinReward uint32
+		expected   uint32
+	}{
+		{"Small reward", 7, 70},
+		{"Largest reward without clamping", math.MaxUint32 / 10, 4294967290},
+		{"Reward beyond uint32 is clamped", math.MaxUint32/10 + 1, math.MaxUint32},
+		{"Largest reward", math.MaxUint32, math.MaxUint32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rep := CalculateExpeditionRep(tt.coinReward); rep != tt.expected {
+				t.Errorf("CalculateExpeditionRep(%d) = %d, want %d", tt.coinReward, rep, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplyLevelPenalty(t *testing.T) {
+	tests := []struct {
+		name             string
+		juniorLevel      uint16
+		seniorLevel      uint16
+		amount           uint32
+		expectedCredited uint32
+		expectedLost     uint32
+	}{
+		{"Junior lower level", 40, 50, 100, 100, 0},
+		{"Same level", 50, 50, 100, 100, 0},
+		{"Junior outlevels senior", 51, 50, 100, 50, 50},
+		{"Odd amount rounds in favour of loss", 60, 50, 5, 2, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credited, lost := ApplyLevelPenalty(tt.juniorLevel, tt.seniorLevel, tt.amount)
+			if credited != tt.expectedCredited || lost != tt.expectedLost {
+				t.Errorf("ApplyLevelPenalty(%d, %d, %d) = (%d, %d), want (%d, %d)",
+					tt.juniorLevel, tt.seniorLevel, tt.amount, credited, lost, tt.expectedCredited, tt.expectedLost)
+			}
+		})
+	}
+}
+
+func TestRepShare(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   uint32
+		share    uint32
+		expected uint32
+	}{
+		{"Full share", 100, 100, 100},
+		{"Half share", 5, 50, 2},
+		{"Large amount does not overflow", math.MaxUint32, 50, math.MaxUint32 / 2},
+		{"Share above a whole is clamped", math.MaxUint32, 200, math.MaxUint32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RepShare(tt.amount, tt.share); got != tt.expected {
+				t.Errorf("RepShare(%d, %d) = %d, want %d", tt.amount, tt.share, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFamilyMember_RemainingDailyRep(t *testing.T) {
+	tests := []struct {
+		name     string
+		dailyRep uint32
+		expected uint32
+	}{
+		{"No rep today", 0, 5000},
+		{"Partial headroom", 4900, 100},
+		{"At cap", 5000, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			member, err := NewBuilder(12345, uuid.New(), 50, 1).SetDailyRep(tt.dailyRep).Build()
+			if err != nil {
+				t.Fatalf("Failed to build family member: %v", err)
+			}
+			if remaining := member.RemainingDailyRep(); remaining != tt.expected {
+				t.Errorf("RemainingDailyRep() with dailyRep %d = %d, want %d", tt.dailyRep, remaining, tt.expected)
+			}
+		})
+	}
+}
+
 func TestFamilyMember_Immutability(t *testing.T) {
 	characterId := uint32(12345)
 	tenantId := uuid.New()
@@ -285,4 +406,29 @@ func TestBuilder_Validation(t *testing.T) {
 			t.Error("Builder should return error for self-reference as senior")
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestWouldCreateCycle(t *testing.T) {
+	tests := []struct {
+		name           string
+		seniorId       uint32
+		seniorAncestry []uint32
+		juniorId       uint32
+		expected       bool
+	}{
+		{"Unrelated junior", 3000, []uint32{2000, 1000}, 4000, false},
+		{"Junior is senior", 3000, []uint32{2000, 1000}, 3000, true},
+		{"Junior is direct senior", 3000, []uint32{2000, 1000}, 2000, true},
+		{"Junior is root ancestor", 3000, []uint32{2000, 1000}, 1000, true},
+		{"Senior without ancestry", 3000, []uint32{}, 1000, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := WouldCreateCycle(tt.seniorId, tt.seniorAncestry, tt.juniorId); result != tt.expected {
+				t.Errorf("WouldCreateCycle(%d, %v, %d) = %v, want %v",
+					tt.seniorId, tt.seniorAncestry, tt.juniorId, result, tt.expected)
+			}
+		})
+	}
+}
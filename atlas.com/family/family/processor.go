@@ -3,10 +3,19 @@ package family
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"atlas-family/database"
+	"atlas-family/idempotency"
 	"atlas-family/kafka/message"
+	charactermsg "atlas-family/kafka/message/character"
 	familymsg "atlas-family/kafka/message/family"
-	"atlas-family/kafka/producer"
+	"atlas-family/ledger"
+	"atlas-family/location"
+	"atlas-family/outbox"
+	"atlas-family/textfilter"
+	"atlas-family/tombstone"
 
 	"github.com/Chronicle20/atlas-model/model"
 	tenant "github.com/Chronicle20/atlas-tenant"
@@ -18,39 +27,81 @@ import (
 // Processor interface defines the core business logic operations
 type Processor interface {
 	WithTransaction(db *gorm.DB) Processor
+	WithTransactionId(transactionId uuid.UUID) Processor
+	WithAutoEnroll() Processor
+	WithLocation(characterId uint32, m location.Model) Processor
+	CreateMember(buf *message.Buffer) func(worldId byte, characterId uint32, level uint16) model.Provider[FamilyMember]
 	AddJunior(buf *message.Buffer) func(worldId byte, seniorId uint32, seniorLevel uint16, juniorId uint32, juniorLevel uint16) model.Provider[FamilyMember]
 	RemoveMember(buf *message.Buffer) func(characterId uint32, reason string) model.Provider[[]FamilyMember]
-	BreakLink(buf *message.Buffer) func(characterId uint32, reason string) model.Provider[[]FamilyMember]
+	BreakLink(buf *message.Buffer) func(characterId uint32) model.Provider[[]FamilyMember]
+	LeaveSenior(buf *message.Buffer) func(characterId uint32) model.Provider[[]FamilyMember]
+	ExpelJunior(buf *message.Buffer) func(characterId uint32, juniorId uint32) model.Provider[[]FamilyMember]
+	ClearCooldown(buf *message.Buffer) func(characterId uint32) model.Provider[FamilyMember]
+	DissolveFamily(buf *message.Buffer) func(characterId uint32) model.Provider[[]FamilyMember]
 	AwardRep(buf *message.Buffer) func(characterId uint32, amount uint32, source string) model.Provider[FamilyMember]
+	PropagateRep(buf *message.Buffer) func(juniorId uint32, amount uint32, source string) model.Provider[[]FamilyMember]
 	DeductRep(buf *message.Buffer) func(characterId uint32, amount uint32, reason string) model.Provider[FamilyMember]
+	RegisterActivity(buf *message.Buffer) func(characterId uint32, activityType string, amount uint32) model.Provider[FamilyMember]
 	ResetDailyRep(buf *message.Buffer) model.Provider[BatchResetResult]
+	UpdateLevel(buf *message.Buffer) func(characterId uint32, level uint16) model.Provider[FamilyMember]
+	DeleteCharacter(buf *message.Buffer) func(characterId uint32) model.Provider[[]FamilyMember]
+	RejectDeleted(buf *message.Buffer) func(worldId byte, characterId uint32) error
+	ChangeWorld(buf *message.Buffer) func(characterId uint32, worldId byte) model.Provider[FamilyMember]
 
 	// AndEmit variants for Kafka message emission
+	CreateMemberAndEmit(transactionId uuid.UUID, worldId byte, characterId uint32, level uint16) model.Provider[FamilyMember]
 	AddJuniorAndEmit(transactionId uuid.UUID, worldId byte, seniorId uint32, seniorLevel uint16, juniorId uint32, juniorLevel uint16) model.Provider[FamilyMember]
 	RemoveMemberAndEmit(transactionId uuid.UUID, characterId uint32, reason string) model.Provider[[]FamilyMember]
-	BreakLinkAndEmit(transactionId uuid.UUID, characterId uint32, reason string) model.Provider[[]FamilyMember]
+	BreakLinkAndEmit(transactionId uuid.UUID, characterId uint32) model.Provider[[]FamilyMember]
+	LeaveSeniorAndEmit(transactionId uuid.UUID, characterId uint32) model.Provider[[]FamilyMember]
+	ExpelJuniorAndEmit(transactionId uuid.UUID, characterId uint32, juniorId uint32) model.Provider[[]FamilyMember]
+	ClearCooldownAndEmit(transactionId uuid.UUID, characterId uint32) model.Provider[FamilyMember]
+	DissolveFamilyAndEmit(transactionId uuid.UUID, characterId uint32) model.Provider[[]FamilyMember]
 	AwardRepAndEmit(transactionId uuid.UUID, characterId uint32, amount uint32, source string) model.Provider[FamilyMember]
+	PropagateRepAndEmit(transactionId uuid.UUID, juniorId uint32, amount uint32, source string) model.Provider[[]FamilyMember]
 	DeductRepAndEmit(transactionId uuid.UUID, characterId uint32, amount uint32, reason string) model.Provider[FamilyMember]
+	RegisterActivityAndEmit(transactionId uuid.UUID, characterId uint32, activityType string, amount uint32) model.Provider[FamilyMember]
+	UpdateLevelAndEmit(transactionId uuid.UUID, characterId uint32, level uint16) model.Provider[FamilyMember]
+	DeleteCharacterAndEmit(transactionId uuid.UUID, characterId uint32) model.Provider[[]FamilyMember]
+	RejectDeletedAndEmit(worldId byte, characterId uint32) error
+	ChangeWorldAndEmit(transactionId uuid.UUID, characterId uint32, worldId byte) model.Provider[FamilyMember]
+	RenameFamily(buf *message.Buffer) func(familyId uint32, characterId uint32, name string) model.Provider[Family]
+	RenameFamilyAndEmit(transactionId uuid.UUID, familyId uint32, characterId uint32, name string) model.Provider[Family]
+	SetPrecept(buf *message.Buffer) func(familyId uint32, characterId uint32, precept string) model.Provider[Family]
+	SetPreceptAndEmit(transactionId uuid.UUID, familyId uint32, characterId uint32, precept string) model.Provider[Family]
+	SetNotice(buf *message.Buffer) func(familyId uint32, characterId uint32, notice string) model.Provider[Family]
+	SetNoticeAndEmit(transactionId uuid.UUID, familyId uint32, characterId uint32, notice string) model.Provider[Family]
 
 	GetFamilyTree(characterId uint32) ([]FamilyMember, error)
+	GetPedigree(characterId uint32, maxDepth uint32) (Pedigree, error)
 	GetByCharacterId(characterId uint32) (FamilyMember, error)
+	GetFamilyById(familyId uint32) (Family, error)
+	GetFamilyByMemberId(characterId uint32) (Family, error)
 }
 
 // ProcessorImpl implements the Processor interface
 type ProcessorImpl struct {
-	log      logrus.FieldLogger
-	ctx      context.Context
-	db       *gorm.DB
-	producer producer.Provider
+	log                 logrus.FieldLogger
+	ctx                 context.Context
+	db                  *gorm.DB
+	t                   tenant.Model
+	repPropagationSplit []uint32
+	textFilter          textfilter.Filter
+	locations           location.LocationProvider
+	transactionId       uuid.UUID
+	autoEnroll          bool
 }
 
 // NewProcessor creates a new processor instance
 func NewProcessor(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) Processor {
 	return &ProcessorImpl{
-		log:      l,
-		ctx:      ctx,
-		db:       db,
-		producer: producer.ProviderImpl(l)(ctx),
+		log:                 l,
+		ctx:                 ctx,
+		db:                  db,
+		t:                   tenant.MustFromContext(ctx),
+		repPropagationSplit: RepPropagationSplit(),
+		textFilter:          textfilter.Default(),
+		locations:           location.Default(),
 	}
 }
 
@@ -62,23 +113,139 @@ var (
 	ErrSeniorHasTooManyJuniors = errors.New("senior already has maximum number of juniors")
 	ErrJuniorAlreadyLinked     = errors.New("junior is already linked to a senior")
 	ErrLevelDifferenceTooLarge = errors.New("level difference exceeds maximum allowed")
+	ErrNotSameWorld            = errors.New("members must be on the same world to link")
 	ErrNotOnSameMap            = errors.New("members must be on the same map to link")
+	ErrLocationUnavailable     = errors.New("member location could not be resolved")
 	ErrInsufficientRep         = errors.New("insufficient reputation for operation")
 	ErrRepCapExceeded          = errors.New("daily reputation cap exceeded")
 	ErrCannotRemoveSelf        = errors.New("cannot remove self from family")
 	ErrNoLinkToBreak           = errors.New("no family link exists to break")
+	ErrNoSenior                = errors.New("member has no senior to leave")
+	ErrNotJunior               = errors.New("character is not a junior of the senior")
+	ErrCooldownActive          = errors.New("junior may not join a family until their cooldown ends")
+	ErrCycleDetected           = errors.New("link would create a circular family relationship")
+	ErrFamilyNotFound          = errors.New("family not found")
+	ErrNotFamilyLeader         = errors.New("only the family leader can perform this operation")
+	ErrCharacterDeleted        = fmt.Errorf("character has been deleted: %w", ErrMemberNotFound)
 )
 
+// ConflictError reports that a member was modified by another operation between being read and being saved. It
+// matches database.ErrConflict so callers can retry the whole operation.
+type ConflictError struct {
+	CharacterId uint32
+	Version     uint32
+}
+
+func (e ConflictError) Error() string {
+	return fmt.Sprintf("family member [%d] was modified concurrently since version [%d]", e.CharacterId, e.Version)
+}
+
+func (e ConflictError) Unwrap() error {
+	return database.ErrConflict
+}
+
 func (p *ProcessorImpl) WithTransaction(db *gorm.DB) Processor {
+	return p.withTransaction(db)
+}
+
+func (p *ProcessorImpl) withTransaction(db *gorm.DB) *ProcessorImpl {
 	return &ProcessorImpl{
-		log:      p.log,
-		ctx:      p.ctx,
-		db:       db,
-		producer: p.producer,
+		log:                 p.log,
+		ctx:                 p.ctx,
+		db:                  db,
+		t:                   p.t,
+		repPropagationSplit: p.repPropagationSplit,
+		textFilter:          p.textFilter,
+		locations:           p.locations,
+		transactionId:       p.transactionId,
+		autoEnroll:          p.autoEnroll,
+	}
+}
+
+// WithTransactionId returns a processor which attributes reputation ledger entries to the given transaction
+func (p *ProcessorImpl) WithTransactionId(transactionId uuid.UUID) Processor {
+	return p.withTransactionId(transactionId)
+}
+
+func (p *ProcessorImpl) withTransactionId(transactionId uuid.UUID) *ProcessorImpl {
+	tp := p.withTransaction(p.db)
+	tp.transactionId = transactionId
+	return tp
+}
+
+// WithAutoEnroll returns a processor whose AddJunior enrolls a senior or junior who is not yet a family member, using
+// the supplied levels and world, instead of rejecting the link
+func (p *ProcessorImpl) WithAutoEnroll() Processor {
+	tp := p.withTransaction(p.db)
+	tp.autoEnroll = true
+	return tp
+}
+
+// CreateMember enrolls a character as a family member without any link
+func (p *ProcessorImpl) CreateMember(buf *message.Buffer) func(worldId byte, characterId uint32, level uint16) model.Provider[FamilyMember] {
+	return func(worldId byte, characterId uint32, level uint16) model.Provider[FamilyMember] {
+		return func() (FamilyMember, error) {
+			m, err := p.createMember(worldId, characterId, level)
+			if err != nil {
+				errorCode := "CREATE_MEMBER_FAILED"
+				switch {
+				case errors.Is(err, ErrMemberAlreadyExists):
+					errorCode = "MEMBER_ALREADY_EXISTS"
+				case errors.Is(err, ErrCharacterDeleted):
+					errorCode = "CHARACTER_DELETED"
+				case errors.Is(err, ErrInvalidCharacterId), errors.Is(err, ErrInvalidLevel):
+					errorCode = "INVALID_MEMBER"
+				}
+
+				if buf != nil {
+					if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(worldId, characterId, 0, 0, errorCode, err.Error())); putErr != nil {
+						p.log.WithError(putErr).Error("Failed to add link error event to buffer")
+					}
+				}
+				return FamilyMember{}, err
+			}
+
+			if buf != nil {
+				if putErr := buf.Put(familymsg.EnvEventTopicStatus, MemberCreatedEventProvider(m.World(), m.CharacterId(), m.Level())); putErr != nil {
+					p.log.WithError(putErr).Error("Failed to add member created event to buffer")
+				}
+			}
+			return m, nil
+		}
 	}
 }
 
-// AddJunior adds a junior to a senior's family
+func (p *ProcessorImpl) createMember(worldId byte, characterId uint32, level uint16) (FamilyMember, error) {
+	deleted, err := tombstone.ExistsProvider(p.t.Id(), characterId)(p.db)()
+	if err != nil {
+		return FamilyMember{}, err
+	}
+	if deleted {
+		return FamilyMember{}, ErrCharacterDeleted
+	}
+	return model.Map(Make)(CreateMember(p.db, p.log)(characterId, p.t.Id(), level, worldId))()
+}
+
+// enroll builds an unsaved member for a character which is not yet a family member. It is created when saved.
+func (p *ProcessorImpl) enroll(worldId byte, characterId uint32, level uint16) (FamilyMember, error) {
+	p.log.WithFields(logrus.Fields{
+		"characterId": characterId,
+		"level":       level,
+		"world":       worldId,
+	}).Info("Enrolling character as family member")
+	return NewBuilder(characterId, p.t.Id(), level, worldId).Build()
+}
+
+// WithLocation returns a processor which takes m as the character's location when linking, instead of resolving it
+// from the location provider
+func (p *ProcessorImpl) WithLocation(characterId uint32, m location.Model) Processor {
+	tp := p.withTransaction(p.db)
+	tp.locations = location.WithKnown(p.locations, characterId, m)
+	return tp
+}
+
+// AddJunior adds a junior to a senior's family. In auto-enroll mode a senior or junior who is not yet a member is
+// enrolled from the supplied levels and world as part of the link.
 func (p *ProcessorImpl) AddJunior(buf *message.Buffer) func(worldId byte, seniorId uint32, seniorLevel uint16, juniorId uint32, juniorLevel uint16) model.Provider[FamilyMember] {
 	return func(worldId byte, seniorId uint32, seniorLevel uint16, juniorId uint32, juniorLevel uint16) model.Provider[FamilyMember] {
 		return func() (FamilyMember, error) {
@@ -97,12 +264,24 @@ func (p *ProcessorImpl) AddJunior(buf *message.Buffer) func(worldId byte, senior
 				return FamilyMember{}, ErrSelfReference
 			}
 
+			// Members enrolled by this link, created when the link is saved
+			var enrolled []FamilyMember
+
 			// Get senior member
 			seniorModel, err := p.GetByCharacterId(seniorId)
 			if err != nil {
-				if errors.Is(err, ErrMemberNotFound) {
-					t := tenant.MustFromContext(p.ctx)
-					seniorModel, err = model.Map(Make)(CreateMember(p.db, p.log)(seniorId, t.Id(), seniorLevel, worldId))()
+				if errors.Is(err, ErrCharacterDeleted) {
+					if buf != nil {
+						if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(0, seniorId, seniorId, juniorId, "CHARACTER_DELETED", err.Error())); putErr != nil {
+							p.log.WithError(putErr).Error("Failed to add link error event to buffer")
+						}
+					}
+					return FamilyMember{}, err
+				}
+				if !errors.Is(err, ErrMemberNotFound) {
+					return FamilyMember{}, err
+				}
+				if !p.autoEnroll {
 					if buf != nil {
 						if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(0, seniorId, seniorId, juniorId, "SENIOR_NOT_FOUND", ErrSeniorNotFound.Error())); putErr != nil {
 							p.log.WithError(putErr).Error("Failed to add link error event to buffer")
@@ -110,7 +289,15 @@ func (p *ProcessorImpl) AddJunior(buf *message.Buffer) func(worldId byte, senior
 					}
 					return FamilyMember{}, ErrSeniorNotFound
 				}
-				return FamilyMember{}, err
+				if seniorModel, err = p.enroll(worldId, seniorId, seniorLevel); err != nil {
+					if buf != nil {
+						if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(worldId, seniorId, seniorId, juniorId, "INVALID_MEMBER", err.Error())); putErr != nil {
+							p.log.WithError(putErr).Error("Failed to add link error event to buffer")
+						}
+					}
+					return FamilyMember{}, err
+				}
+				enrolled = append(enrolled, seniorModel)
 			}
 
 			// Check if senior can add more juniors
@@ -126,7 +313,18 @@ func (p *ProcessorImpl) AddJunior(buf *message.Buffer) func(worldId byte, senior
 			// Get junior member
 			juniorModel, err := p.GetByCharacterId(juniorId)
 			if err != nil {
-				if errors.Is(err, ErrMemberNotFound) {
+				if errors.Is(err, ErrCharacterDeleted) {
+					if buf != nil {
+						if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(seniorModel.World(), seniorId, seniorId, juniorId, "CHARACTER_DELETED", err.Error())); putErr != nil {
+							p.log.WithError(putErr).Error("Failed to add link error event to buffer")
+						}
+					}
+					return FamilyMember{}, err
+				}
+				if !errors.Is(err, ErrMemberNotFound) {
+					return FamilyMember{}, err
+				}
+				if !p.autoEnroll {
 					if buf != nil {
 						if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(seniorModel.World(), seniorId, seniorId, juniorId, "JUNIOR_NOT_FOUND", ErrJuniorNotFound.Error())); putErr != nil {
 							p.log.WithError(putErr).Error("Failed to add link error event to buffer")
@@ -134,7 +332,15 @@ func (p *ProcessorImpl) AddJunior(buf *message.Buffer) func(worldId byte, senior
 					}
 					return FamilyMember{}, ErrJuniorNotFound
 				}
-				return FamilyMember{}, err
+				if juniorModel, err = p.enroll(worldId, juniorId, juniorLevel); err != nil {
+					if buf != nil {
+						if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(seniorModel.World(), seniorId, seniorId, juniorId, "INVALID_MEMBER", err.Error())); putErr != nil {
+							p.log.WithError(putErr).Error("Failed to add link error event to buffer")
+						}
+					}
+					return FamilyMember{}, err
+				}
+				enrolled = append(enrolled, juniorModel)
 			}
 
 			// Check if junior already has a senior
@@ -147,6 +353,16 @@ func (p *ProcessorImpl) AddJunior(buf *message.Buffer) func(worldId byte, senior
 				return FamilyMember{}, ErrJuniorAlreadyLinked
 			}
 
+			// Check if junior recently left or was expelled from a family
+			if juniorModel.IsOnCooldown(time.Now()) {
+				if buf != nil {
+					if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(seniorModel.World(), seniorId, seniorId, juniorId, "COOLDOWN_ACTIVE", ErrCooldownActive.Error())); putErr != nil {
+						p.log.WithError(putErr).Error("Failed to add link error event to buffer")
+					}
+				}
+				return FamilyMember{}, ErrCooldownActive
+			}
+
 			// Validate level difference
 			if !seniorModel.ValidateLevelDifference(juniorModel.Level()) {
 				if buf != nil {
@@ -157,8 +373,31 @@ func (p *ProcessorImpl) AddJunior(buf *message.Buffer) func(worldId byte, senior
 				return FamilyMember{}, ErrLevelDifferenceTooLarge
 			}
 
-			// Validate same world
-			if !seniorModel.IsSameWorld(juniorModel.World()) {
+			// Resolve where both characters are, a side supplied by the caller is not looked up
+			seniorLocation, seniorErr := p.locations.GetLocation(p.log, p.ctx, seniorId)
+			juniorLocation, juniorErr := p.locations.GetLocation(p.log, p.ctx, juniorId)
+			if err = errors.Join(seniorErr, juniorErr); err != nil {
+				err = fmt.Errorf("%w: %w", ErrLocationUnavailable, err)
+				if buf != nil {
+					if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(seniorModel.World(), seniorId, seniorId, juniorId, "LOCATION_UNAVAILABLE", err.Error())); putErr != nil {
+						p.log.WithError(putErr).Error("Failed to add link error event to buffer")
+					}
+				}
+				return FamilyMember{}, err
+			}
+
+			// The requested world is authoritative, both members must belong to it and be found on it
+			if seniorModel.World() != worldId || juniorModel.World() != worldId ||
+				seniorLocation.WorldId() != worldId || juniorLocation.WorldId() != worldId {
+				if buf != nil {
+					if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(seniorModel.World(), seniorId, seniorId, juniorId, "NOT_SAME_WORLD", ErrNotSameWorld.Error())); putErr != nil {
+						p.log.WithError(putErr).Error("Failed to add link error event to buffer")
+					}
+				}
+				return FamilyMember{}, ErrNotSameWorld
+			}
+
+			if !ValidateLocation(seniorLocation.WorldId(), seniorLocation.MapId(), juniorLocation.WorldId(), juniorLocation.MapId()) {
 				if buf != nil {
 					if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(seniorModel.World(), seniorId, seniorId, juniorId, "NOT_ON_SAME_MAP", ErrNotOnSameMap.Error())); putErr != nil {
 						p.log.WithError(putErr).Error("Failed to add link error event to buffer")
@@ -170,6 +409,15 @@ func (p *ProcessorImpl) AddJunior(buf *message.Buffer) func(worldId byte, senior
 			// Begin transaction
 			var result FamilyMember
 			err = p.db.Transaction(func(tx *gorm.DB) error {
+				// Reject the link if the junior is already an ancestor of the senior
+				ancestry, err := GetAncestryProvider(p.t.Id(), seniorId)(tx)()
+				if err != nil {
+					return err
+				}
+				if WouldCreateCycle(seniorId, ancestry, juniorId) {
+					return ErrCycleDetected
+				}
+
 				// Update senior - add junior
 				updatedSenior, err := seniorModel.Builder().
 					AddJunior(juniorId).
@@ -199,22 +447,37 @@ func (p *ProcessorImpl) AddJunior(buf *message.Buffer) func(worldId byte, senior
 					return err
 				}
 
-				result = updatedSenior
-				return nil
+				// The junior's tree now belongs to the senior's family
+				if err := p.syncFamilies(tx, seniorId); err != nil {
+					return err
+				}
+
+				result, err = p.withTransaction(tx).GetByCharacterId(seniorId)
+				return err
 			})
 
 			if err != nil {
+				errorCode := "ADD_JUNIOR_FAILED"
+				if errors.Is(err, ErrCycleDetected) {
+					errorCode = "CYCLE_DETECTED"
+				}
+
 				// Add error event to buffer if provided
 				if buf != nil {
-					if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(seniorModel.World(), seniorId, seniorId, juniorId, "ADD_JUNIOR_FAILED", err.Error())); putErr != nil {
+					if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(seniorModel.World(), seniorId, seniorId, juniorId, errorCode, err.Error())); putErr != nil {
 						p.log.WithError(putErr).Error("Failed to add link error event to buffer")
 					}
 				}
 				return FamilyMember{}, err
 			}
 
-			// Add success event to buffer if provided
+			// Add success events to buffer if provided
 			if buf != nil {
+				for _, m := range enrolled {
+					if putErr := buf.Put(familymsg.EnvEventTopicStatus, MemberCreatedEventProvider(m.World(), m.CharacterId(), m.Level())); putErr != nil {
+						p.log.WithError(putErr).Error("Failed to add member created event to buffer")
+					}
+				}
 				if putErr := buf.Put(familymsg.EnvEventTopicStatus, LinkCreatedEventProvider(result.World(), seniorId, seniorId, juniorId)); putErr != nil {
 					p.log.WithError(putErr).Error("Failed to add link created event to buffer")
 				}
@@ -244,7 +507,7 @@ func (p *ProcessorImpl) RemoveMember(buf *message.Buffer) func(characterId uint3
 			err = p.db.Transaction(func(tx *gorm.DB) error {
 				// If member has a senior, remove from senior's junior list
 				if memberModel.HasSenior() {
-					if seniorModel, err := p.GetByCharacterId(*memberModel.SeniorId()); err == nil {
+					if seniorModel, err := p.WithTransaction(tx).GetByCharacterId(*memberModel.SeniorId()); err == nil {
 						updatedSenior, err := seniorModel.Builder().
 							RemoveJunior(characterId).
 							Touch().
@@ -263,7 +526,7 @@ func (p *ProcessorImpl) RemoveMember(buf *message.Buffer) func(characterId uint3
 				// If member has juniors, remove their senior reference
 				if memberModel.HasJuniors() {
 					for _, juniorId := range memberModel.JuniorIds() {
-						if juniorModel, err := p.GetByCharacterId(juniorId); err == nil {
+						if juniorModel, err := p.WithTransaction(tx).GetByCharacterId(juniorId); err == nil {
 							updatedJunior, err := juniorModel.Builder().
 								ClearSeniorId().
 								Touch().
@@ -281,11 +544,11 @@ func (p *ProcessorImpl) RemoveMember(buf *message.Buffer) func(characterId uint3
 				}
 
 				// Remove the member
-				if _, err := DeleteMember(tx, p.log)(characterId)(); err != nil {
+				if _, err := DeleteMember(tx, p.log)(p.t.Id(), characterId)(); err != nil {
 					return err
 				}
 
-				return nil
+				return p.syncFamilies(tx, familySyncIds(memberModel)...)
 			})
 
 			return updatedMembers, err
@@ -293,14 +556,72 @@ func (p *ProcessorImpl) RemoveMember(buf *message.Buffer) func(characterId uint3
 	}
 }
 
-// BreakLink breaks the family link for a character
-func (p *ProcessorImpl) BreakLink(buf *message.Buffer) func(characterId uint32, reason string) model.Provider[[]FamilyMember] {
-	return func(characterId uint32, reason string) model.Provider[[]FamilyMember] {
+// DeleteCharacter cleans up after a character deleted elsewhere. The character is tombstoned so later commands for it
+// are rejected, and if they were a member they are removed from the family, dissolving their links.
+func (p *ProcessorImpl) DeleteCharacter(buf *message.Buffer) func(characterId uint32) model.Provider[[]FamilyMember] {
+	return func(characterId uint32) model.Provider[[]FamilyMember] {
 		return func() ([]FamilyMember, error) {
-			p.log.WithFields(logrus.Fields{
-				"characterId": characterId,
-				"reason":      reason,
-			}).Info("Breaking family link")
+			p.log.WithField("characterId", characterId).Info("Cleaning up deleted character")
+
+			memberModel, err := p.GetByCharacterId(characterId)
+			if errors.Is(err, ErrCharacterDeleted) {
+				return []FamilyMember{}, nil
+			}
+			if err != nil && !errors.Is(err, ErrMemberNotFound) {
+				return []FamilyMember{}, err
+			}
+			member := err == nil
+
+			if _, err := tombstone.Record(p.db, p.log)(p.t.Id(), characterId)(); err != nil {
+				return []FamilyMember{}, err
+			}
+			if !member {
+				return []FamilyMember{}, nil
+			}
+
+			updatedMembers, err := p.RemoveMember(buf)(characterId, string(ReasonCharacterDeleted))()
+			if err != nil {
+				return []FamilyMember{}, err
+			}
+
+			// The deleted character is affected along with everyone who lost a link to them
+			affectedIds := make([]uint32, 0, len(updatedMembers)+1)
+			affectedIds = append(affectedIds, characterId)
+			for _, m := range updatedMembers {
+				affectedIds = append(affectedIds, m.CharacterId())
+			}
+			if buf != nil {
+				if putErr := buf.Put(familymsg.EnvEventTopicStatus, TreeDissolvedEventProvider(memberModel.World(), characterId, characterId, affectedIds, ReasonCharacterDeleted)); putErr != nil {
+					p.log.WithError(putErr).Error("Failed to add tree dissolved event to buffer")
+				}
+			}
+			return updatedMembers, nil
+		}
+	}
+}
+
+// RejectDeleted fails with ErrCharacterDeleted when the character has been deleted
+func (p *ProcessorImpl) RejectDeleted(buf *message.Buffer) func(worldId byte, characterId uint32) error {
+	return func(worldId byte, characterId uint32) error {
+		deleted, err := tombstone.ExistsProvider(p.t.Id(), characterId)(p.db)()
+		if err != nil || !deleted {
+			return err
+		}
+
+		if buf != nil {
+			if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(worldId, characterId, characterId, 0, "CHARACTER_DELETED", ErrCharacterDeleted.Error())); putErr != nil {
+				p.log.WithError(putErr).Error("Failed to add link error event to buffer")
+			}
+		}
+		return ErrCharacterDeleted
+	}
+}
+
+// BreakLink breaks every family link of a character, reporting each with ReasonBreakLink
+func (p *ProcessorImpl) BreakLink(buf *message.Buffer) func(characterId uint32) model.Provider[[]FamilyMember] {
+	return func(characterId uint32) model.Provider[[]FamilyMember] {
+		return func() ([]FamilyMember, error) {
+			p.log.WithField("characterId", characterId).Info("Breaking family link")
 
 			// Get the member
 			memberModel, err := p.GetByCharacterId(characterId)
@@ -313,8 +634,14 @@ func (p *ProcessorImpl) BreakLink(buf *message.Buffer) func(characterId uint32,
 				return []FamilyMember{}, ErrNoLinkToBreak
 			}
 
+			// Every junior left without a senior starts the tenant's break cooldown
+			now := time.Now()
+			cooldown := memberModel.Rules().BreakCooldown()
+
 			var updatedMembers []FamilyMember
 			err = p.db.Transaction(func(tx *gorm.DB) error {
+				current := memberModel
+
 				// If member has a senior, remove from senior's junior list
 				if memberModel.HasSenior() {
 					if seniorModel, err := p.WithTransaction(tx).GetByCharacterId(*memberModel.SeniorId()); err == nil {
@@ -332,18 +659,25 @@ func (p *ProcessorImpl) BreakLink(buf *message.Buffer) func(characterId uint32,
 						updatedMembers = append(updatedMembers, updatedSenior)
 					}
 					// Clear member's senior reference
-					updatedMember, err := memberModel.Builder().
+					updatedMember, err := current.Builder().
 						ClearSeniorId().
+						StartCooldown(now, cooldown).
 						Touch().
 						Build()
 					if err != nil {
 						return err
 					}
 
-					if _, err := SaveMember(tx, p.log)(updatedMember)(); err != nil {
+					saved, err := SaveMember(tx, p.log)(updatedMember)()
+					if err != nil {
 						return err
 					}
 					updatedMembers = append(updatedMembers, updatedMember)
+
+					// Continue from the saved version so the junior list can be cleared below
+					if current, err = Make(saved); err != nil {
+						return err
+					}
 				}
 
 				// If member has juniors, clear their senior reference
@@ -352,6 +686,7 @@ func (p *ProcessorImpl) BreakLink(buf *message.Buffer) func(characterId uint32,
 						if juniorModel, err := p.WithTransaction(tx).GetByCharacterId(juniorId); err == nil {
 							updatedJunior, err := juniorModel.Builder().
 								ClearSeniorId().
+								StartCooldown(now, cooldown).
 								Touch().
 								Build()
 							if err != nil {
@@ -366,7 +701,7 @@ func (p *ProcessorImpl) BreakLink(buf *message.Buffer) func(characterId uint32,
 					}
 
 					// Clear member's junior list
-					updatedMember, err := memberModel.Builder().
+					updatedMember, err := current.Builder().
 						SetJuniorIds([]uint32{}).
 						Touch().
 						Build()
@@ -390,7 +725,7 @@ func (p *ProcessorImpl) BreakLink(buf *message.Buffer) func(characterId uint32,
 					}
 				}
 
-				return nil
+				return p.syncFamilies(tx, familySyncIds(memberModel)...)
 			})
 
 			if err != nil {
@@ -400,13 +735,13 @@ func (p *ProcessorImpl) BreakLink(buf *message.Buffer) func(characterId uint32,
 			// Add link broken events to buffer for all affected relationships
 			if buf != nil {
 				if memberModel.HasSenior() {
-					if putErr := buf.Put(familymsg.EnvEventTopicStatus, LinkBrokenEventProvider(memberModel.World(), characterId, *memberModel.SeniorId(), characterId, reason)); putErr != nil {
+					if putErr := buf.Put(familymsg.EnvEventTopicStatus, LinkBrokenEventProvider(memberModel.World(), characterId, *memberModel.SeniorId(), characterId, ReasonBreakLink)); putErr != nil {
 						p.log.WithError(putErr).Error("Failed to add link broken event to buffer for senior")
 					}
 				}
 
 				for _, juniorId := range memberModel.JuniorIds() {
-					if putErr := buf.Put(familymsg.EnvEventTopicStatus, LinkBrokenEventProvider(memberModel.World(), characterId, characterId, juniorId, reason)); putErr != nil {
+					if putErr := buf.Put(familymsg.EnvEventTopicStatus, LinkBrokenEventProvider(memberModel.World(), characterId, characterId, juniorId, ReasonBreakLink)); putErr != nil {
 						p.log.WithError(putErr).Error("Failed to add link broken event to buffer for junior")
 					}
 				}
@@ -417,193 +752,1092 @@ func (p *ProcessorImpl) BreakLink(buf *message.Buffer) func(characterId uint32,
 	}
 }
 
-// AwardRep awards reputation to a character
-func (p *ProcessorImpl) AwardRep(buf *message.Buffer) func(characterId uint32, amount uint32, source string) model.Provider[FamilyMember] {
-	return func(characterId uint32, amount uint32, source string) model.Provider[FamilyMember] {
-		return func() (FamilyMember, error) {
-			p.log.WithFields(logrus.Fields{
-				"characterId": characterId,
-				"amount":      amount,
-				"source":      source,
-			}).Info("Awarding reputation")
+// LeaveSenior breaks the link between a junior and their senior at the junior's request. The junior pays the tenant's
+// leave cost and starts the leave cooldown.
+func (p *ProcessorImpl) LeaveSenior(buf *message.Buffer) func(characterId uint32) model.Provider[[]FamilyMember] {
+	return func(characterId uint32) model.Provider[[]FamilyMember] {
+		return func() ([]FamilyMember, error) {
+			p.log.WithField("characterId", characterId).Info("Leaving senior")
 
-			// Get the member
-			memberModel, err := p.GetByCharacterId(characterId)
+			juniorModel, err := p.GetByCharacterId(characterId)
 			if err != nil {
-				return FamilyMember{}, err
+				return []FamilyMember{}, err
 			}
 
-			// Check if member can receive more rep today
-			if !memberModel.CanReceiveRep(amount) {
-				// Add error event to buffer if provided
+			if !juniorModel.HasSenior() {
 				if buf != nil {
-					if putErr := buf.Put(familymsg.EnvEventTopicErrors, RepErrorEventProvider(memberModel.World(), characterId, "AWARD_REP_FAILED", ErrRepCapExceeded.Error(), amount)); putErr != nil {
-						p.log.WithError(putErr).Error("Failed to add rep error event to buffer")
+					if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(juniorModel.World(), characterId, 0, characterId, "NO_SENIOR", ErrNoSenior.Error())); putErr != nil {
+						p.log.WithError(putErr).Error("Failed to add link error event to buffer")
 					}
 				}
-				return FamilyMember{}, ErrRepCapExceeded
-			}
-
-			// Update member with new rep
-			updatedMember, err := memberModel.Builder().
-				AddRep(amount).
-				AddDailyRep(amount).
-				Touch().
-				Build()
-			if err != nil {
-				return FamilyMember{}, err
-			}
-
-			if _, err := SaveMember(p.db, p.log)(updatedMember)(); err != nil {
-				return FamilyMember{}, err
-			}
-
-			// Add success event to buffer if provided
-			if buf != nil {
-				if putErr := buf.Put(familymsg.EnvEventTopicRep, RepGainedEventProvider(updatedMember.World(), characterId, amount, updatedMember.DailyRep(), source)); putErr != nil {
-					p.log.WithError(putErr).Error("Failed to add rep gained event to buffer")
-				}
+				return []FamilyMember{}, ErrNoSenior
 			}
 
-			return updatedMember, nil
+			r := juniorModel.Rules()
+			return p.severLink(buf, juniorModel, *juniorModel.SeniorId(), characterId, ReasonLeftSenior, "LEAVE_SENIOR_FAILED", r.LeaveRepCost(), r.LeaveCooldown())
 		}
 	}
 }
 
-// DeductRep deducts reputation from a character
-func (p *ProcessorImpl) DeductRep(buf *message.Buffer) func(characterId uint32, amount uint32, reason string) model.Provider[FamilyMember] {
-	return func(characterId uint32, amount uint32, reason string) model.Provider[FamilyMember] {
-		return func() (FamilyMember, error) {
+// ExpelJunior breaks the link between a senior and one of their juniors at the senior's request. The senior pays the
+// tenant's expel cost and the junior starts the expel cooldown.
+func (p *ProcessorImpl) ExpelJunior(buf *message.Buffer) func(characterId uint32, juniorId uint32) model.Provider[[]FamilyMember] {
+	return func(characterId uint32, juniorId uint32) model.Provider[[]FamilyMember] {
+		return func() ([]FamilyMember, error) {
 			p.log.WithFields(logrus.Fields{
-				"characterId": characterId,
-				"amount":      amount,
-				"reason":      reason,
-			}).Info("Deducting reputation")
+				"seniorId": characterId,
+				"juniorId": juniorId,
+			}).Info("Expelling junior")
 
-			// Get the member
-			memberModel, err := p.GetByCharacterId(characterId)
+			seniorModel, err := p.GetByCharacterId(characterId)
 			if err != nil {
-				return FamilyMember{}, err
+				return []FamilyMember{}, err
 			}
 
-			// Check if member has enough rep
-			if memberModel.Rep() < amount {
-				// Add error event to buffer if provided
+			if !seniorModel.HasJunior(juniorId) {
 				if buf != nil {
-					if putErr := buf.Put(familymsg.EnvEventTopicErrors, RepErrorEventProvider(memberModel.World(), characterId, "DEDUCT_REP_FAILED", ErrInsufficientRep.Error(), amount)); putErr != nil {
-						p.log.WithError(putErr).Error("Failed to add rep error event to buffer")
+					if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(seniorModel.World(), characterId, characterId, juniorId, "NOT_A_JUNIOR", ErrNotJunior.Error())); putErr != nil {
+						p.log.WithError(putErr).Error("Failed to add link error event to buffer")
 					}
 				}
-				return FamilyMember{}, ErrInsufficientRep
+				return []FamilyMember{}, ErrNotJunior
 			}
 
-			// Update member with deducted rep
-			updatedMember, err := memberModel.Builder().
-				SubtractRep(amount).
-				Touch().
-				Build()
+			r := seniorModel.Rules()
+			return p.severLink(buf, seniorModel, characterId, juniorId, ReasonExpelled, "EXPEL_JUNIOR_FAILED", r.ExpelRepCost(), r.ExpelCooldown())
+		}
+	}
+}
+
+// severLink breaks the single link between a senior and a junior on behalf of the initiator, who pays cost. The junior
+// may not join a family again until the cooldown has passed. A side of the link whose member no longer exists is
+// skipped.
+func (p *ProcessorImpl) severLink(buf *message.Buffer, initiator FamilyMember, seniorId uint32, juniorId uint32, reason LinkBrokenReason, failureCode string, cost uint32, cooldown time.Duration) ([]FamilyMember, error) {
+	characterId := initiator.CharacterId()
+	now := time.Now()
+	var cooldownUntil *time.Time
+	if cooldown > 0 {
+		until := now.Add(cooldown)
+		cooldownUntil = &until
+	}
+
+	var updatedMembers []FamilyMember
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		tp := p.withTransaction(tx)
+
+		// The initiator pays from their current balance
+		payer, err := tp.GetByCharacterId(characterId)
+		if err != nil {
+			return err
+		}
+		if payer.Rep() < cost {
+			return ErrInsufficientRep
+		}
+
+		sever := func(id uint32, apply func(*Builder) *Builder) error {
+			m, err := tp.GetByCharacterId(id)
+			if errors.Is(err, ErrMemberNotFound) {
+				return nil
+			}
 			if err != nil {
-				return FamilyMember{}, err
+				return err
 			}
 
-			if _, err := SaveMember(p.db, p.log)(updatedMember)(); err != nil {
-				return FamilyMember{}, err
+			b := apply(m.Builder()).Touch()
+			paid := uint32(0)
+			if id == characterId {
+				paid = cost
+				b = b.SubtractRep(paid)
+			}
+			updated, err := b.Build()
+			if err != nil {
+				return err
 			}
 
-			// Add success event to buffer if provided
-			if buf != nil {
-				if putErr := buf.Put(familymsg.EnvEventTopicRep, RepRedeemedEventProvider(updatedMember.World(), characterId, amount, reason)); putErr != nil {
-					p.log.WithError(putErr).Error("Failed to add rep redeemed event to buffer")
-				}
+			if paid > 0 {
+				updated, err = tp.saveRepChange(updated, paid, ledger.DirectionDebit, string(reason))
+			} else {
+				_, err = SaveMember(tx, p.log)(updated)()
+			}
+			if err != nil {
+				return err
 			}
+			updatedMembers = append(updatedMembers, updated)
+			return nil
+		}
 
-			return updatedMember, nil
+		if err := sever(seniorId, func(b *Builder) *Builder { return b.RemoveJunior(juniorId) }); err != nil {
+			return err
+		}
+		if err := sever(juniorId, func(b *Builder) *Builder { return b.ClearSeniorId().StartCooldown(now, cooldown) }); err != nil {
+			return err
 		}
-	}
-}
 
-// ResetDailyRep resets daily reputation for all members
-func (p *ProcessorImpl) ResetDailyRep(buf *message.Buffer) model.Provider[BatchResetResult] {
-	return func() (BatchResetResult, error) {
-		p.log.Info("Resetting daily reputation for all members")
+		return p.syncFamilies(tx, seniorId, juniorId)
+	})
 
-		result, err := BatchResetDailyRep(p.db, p.log)()
-		if err != nil {
-			_ = buf.Put(familymsg.EnvEventTopicErrors, RepErrorEventProvider(0, 0, "RESET_FAILED", err.Error(), 0))
-			return BatchResetResult{}, err
+	if err != nil {
+		errorCode := failureCode
+		if errors.Is(err, ErrInsufficientRep) {
+			errorCode = "INSUFFICIENT_REP"
 		}
-
-		_ = buf.Put(familymsg.EnvEventTopicRep, RepResetEventProvider(0, 0, uint32(result.AffectedCount)))
-		return result, nil
+		if buf != nil {
+			if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(initiator.World(), characterId, seniorId, juniorId, errorCode, err.Error())); putErr != nil {
+				p.log.WithError(putErr).Error("Failed to add link error event to buffer")
+			}
+		}
+		return []FamilyMember{}, err
 	}
-}
 
-// AndEmit variants - combine business logic with event emission
-
-// AddJuniorAndEmit adds a junior and emits appropriate events
-func (p *ProcessorImpl) AddJuniorAndEmit(transactionId uuid.UUID, worldId byte, seniorId uint32, seniorLevel uint16, juniorId uint32, juniorLevel uint16) model.Provider[FamilyMember] {
-	return func() (FamilyMember, error) {
-		return message.EmitWithResult[FamilyMember, struct{}](p.producer)(func(buf *message.Buffer) func(struct{}) (FamilyMember, error) {
-			return func(struct{}) (FamilyMember, error) {
-				// Use base function which handles event emission
-				return p.AddJunior(buf)(worldId, seniorId, seniorLevel, juniorId, juniorLevel)()
-			}
-		})(struct{}{})
+	if buf != nil {
+		if putErr := buf.Put(familymsg.EnvEventTopicStatus, LinkSeveredEventProvider(initiator.World(), characterId, seniorId, juniorId, reason, cost, cooldownUntil)); putErr != nil {
+			p.log.WithError(putErr).Error("Failed to add link broken event to buffer")
+		}
 	}
+	return updatedMembers, nil
 }
 
-// RemoveMemberAndEmit removes a member and emits appropriate events
-func (p *ProcessorImpl) RemoveMemberAndEmit(transactionId uuid.UUID, characterId uint32, reason string) model.Provider[[]FamilyMember] {
-	return func() ([]FamilyMember, error) {
-		return message.EmitWithResult[[]FamilyMember, struct{}](p.producer)(func(buf *message.Buffer) func(struct{}) ([]FamilyMember, error) {
-			return func(struct{}) ([]FamilyMember, error) {
-				// Use base function which handles event emission
-				return p.RemoveMember(buf)(characterId, reason)()
-			}
-		})(struct{}{})
+// ClearCooldown lets a member who left or was expelled from a family join one again immediately
+func (p *ProcessorImpl) ClearCooldown(buf *message.Buffer) func(characterId uint32) model.Provider[FamilyMember] {
+	return func(characterId uint32) model.Provider[FamilyMember] {
+		return func() (FamilyMember, error) {
+			p.log.WithField("characterId", characterId).Info("Clearing family cooldown")
+
+			var memberModel FamilyMember
+			var updatedMember FamilyMember
+			cleared := false
+			err := p.db.Transaction(func(tx *gorm.DB) error {
+				var err error
+				memberModel, err = p.withTransaction(tx).GetByCharacterId(characterId)
+				if err != nil {
+					return err
+				}
+				if !memberModel.IsOnCooldown(time.Now()) {
+					updatedMember = memberModel
+					return nil
+				}
+
+				updatedMember, err = memberModel.Builder().
+					ClearCooldown().
+					Touch().
+					Build()
+				if err != nil {
+					return err
+				}
+
+				entity, err := SaveMember(tx, p.log)(updatedMember)()
+				if err != nil {
+					return err
+				}
+				if updatedMember, err = Make(entity); err != nil {
+					return err
+				}
+				cleared = true
+				return nil
+			})
+			if err != nil {
+				return FamilyMember{}, err
+			}
+			if !cleared {
+				return updatedMember, nil
+			}
+
+			if buf != nil {
+				if putErr := buf.Put(familymsg.EnvEventTopicStatus, CooldownClearedEventProvider(updatedMember.World(), characterId, *memberModel.CooldownUntil())); putErr != nil {
+					p.log.WithError(putErr).Error("Failed to add cooldown cleared event to buffer")
+				}
+			}
+			return updatedMember, nil
+		}
+	}
+}
+
+// DissolveFamily breaks every link in the tree led by the given root ancestor, leaving each member without a senior,
+// juniors or family. Only the root may dissolve their family.
+func (p *ProcessorImpl) DissolveFamily(buf *message.Buffer) func(characterId uint32) model.Provider[[]FamilyMember] {
+	return func(characterId uint32) model.Provider[[]FamilyMember] {
+		return func() ([]FamilyMember, error) {
+			p.log.WithField("characterId", characterId).Info("Dissolving family")
+
+			rootModel, err := p.GetByCharacterId(characterId)
+			if err != nil {
+				return []FamilyMember{}, err
+			}
+
+			fail := func(code string, err error) ([]FamilyMember, error) {
+				if buf != nil {
+					if putErr := buf.Put(familymsg.EnvEventTopicErrors, LinkErrorEventProvider(rootModel.World(), characterId, characterId, 0, code, err.Error())); putErr != nil {
+						p.log.WithError(putErr).Error("Failed to add link error event to buffer")
+					}
+				}
+				return []FamilyMember{}, err
+			}
+			if rootModel.HasSenior() {
+				return fail("NOT_FAMILY_LEADER", ErrNotFamilyLeader)
+			}
+			if !rootModel.HasJuniors() {
+				return fail("FAMILY_NOT_FOUND", ErrFamilyNotFound)
+			}
+
+			var updatedMembers []FamilyMember
+			var brokenLinks [][2]uint32
+			err = p.db.Transaction(func(tx *gorm.DB) error {
+				entities, err := GetSubtreeProvider(p.t.Id(), characterId)(tx)()
+				if err != nil {
+					return err
+				}
+
+				for _, e := range entities {
+					memberModel, err := Make(e)
+					if err != nil {
+						return err
+					}
+
+					updatedMember, err := memberModel.Builder().
+						ClearSeniorId().
+						SetJuniorIds([]uint32{}).
+						ClearFamilyId().
+						Touch().
+						Build()
+					if err != nil {
+						return err
+					}
+
+					if _, err := SaveMember(tx, p.log)(updatedMember)(); err != nil {
+						return err
+					}
+					updatedMembers = append(updatedMembers, updatedMember)
+					if memberModel.HasSenior() {
+						brokenLinks = append(brokenLinks, [2]uint32{*memberModel.SeniorId(), memberModel.CharacterId()})
+					}
+				}
+
+				return p.syncFamilies(tx, characterId)
+			})
+			if err != nil {
+				return fail("DISSOLVE_FAMILY_FAILED", err)
+			}
+
+			if buf != nil {
+				affectedIds := make([]uint32, 0, len(updatedMembers))
+				for _, m := range updatedMembers {
+					affectedIds = append(affectedIds, m.CharacterId())
+				}
+				if putErr := buf.Put(familymsg.EnvEventTopicStatus, TreeDissolvedEventProvider(rootModel.World(), characterId, characterId, affectedIds, ReasonFamilyDissolved)); putErr != nil {
+					p.log.WithError(putErr).Error("Failed to add tree dissolved event to buffer")
+				}
+				for _, link := range brokenLinks {
+					if putErr := buf.Put(familymsg.EnvEventTopicStatus, LinkBrokenEventProvider(rootModel.World(), characterId, link[0], link[1], ReasonFamilyDissolved)); putErr != nil {
+						p.log.WithError(putErr).Error("Failed to add link broken event to buffer")
+					}
+				}
+			}
+			return updatedMembers, nil
+		}
+	}
+}
+
+// familySyncIds returns the characters whose families may change when a member's links are severed
+func familySyncIds(memberModel FamilyMember) []uint32 {
+	ids := []uint32{memberModel.CharacterId()}
+	if memberModel.HasSenior() {
+		ids = append(ids, *memberModel.SeniorId())
+	}
+	return append(ids, memberModel.JuniorIds()...)
+}
+
+// syncFamilies reconciles the family aggregate of every tree the given characters belong to
+func (p *ProcessorImpl) syncFamilies(tx *gorm.DB, characterIds ...uint32) error {
+	synced := make(map[uint32]bool)
+	for _, characterId := range characterIds {
+		// Walk to the root however deep the tree is, so the leader and member count reflect the whole family
+		ancestry, err := GetAncestryProvider(p.t.Id(), characterId)(tx)()
+		if err != nil {
+			return err
+		}
+		rootId := characterId
+		for i := len(ancestry) - 1; i >= 0; i-- {
+			// The topmost senior may be a dangling reference to a member who no longer exists
+			exists, err := ExistsProvider(p.t.Id(), ancestry[i])(tx)()
+			if err != nil {
+				return err
+			}
+			if exists {
+				rootId = ancestry[i]
+				break
+			}
+		}
+		if synced[rootId] {
+			continue
+		}
+		synced[rootId] = true
+
+		if _, err := SyncFamily(tx, p.log)(p.t.Id(), rootId)(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AwardRep awards reputation directly to a character. The level penalty only applies to rep a junior passes up to
+// their seniors, see PropagateRep, so a direct award is credited in full.
+func (p *ProcessorImpl) AwardRep(buf *message.Buffer) func(characterId uint32, amount uint32, source string) model.Provider[FamilyMember] {
+	return func(characterId uint32, amount uint32, source string) model.Provider[FamilyMember] {
+		return func() (FamilyMember, error) {
+			p.log.WithFields(logrus.Fields{
+				"characterId": characterId,
+				"amount":      amount,
+				"source":      source,
+			}).Info("Awarding reputation")
+
+			// Get the member
+			memberModel, err := p.GetByCharacterId(characterId)
+			if err != nil {
+				return FamilyMember{}, err
+			}
+
+			return p.creditRep(buf, memberModel, amount, source, 0)
+		}
+	}
+}
+
+// PropagateRep credits rep earned by a junior up the senior chain, applying the configured split per generation
+func (p *ProcessorImpl) PropagateRep(buf *message.Buffer) func(juniorId uint32, amount uint32, source string) model.Provider[[]FamilyMember] {
+	return func(juniorId uint32, amount uint32, source string) model.Provider[[]FamilyMember] {
+		return func() ([]FamilyMember, error) {
+			p.log.WithFields(logrus.Fields{
+				"juniorId": juniorId,
+				"amount":   amount,
+				"source":   source,
+			}).Info("Propagating reputation to seniors")
+
+			recipients := make([]FamilyMember, 0)
+			err := p.db.Transaction(func(tx *gorm.DB) error {
+				tp := p.withTransaction(tx)
+
+				current, err := tp.GetByCharacterId(juniorId)
+				if err != nil {
+					return err
+				}
+				juniorLevel := current.Level()
+
+				for _, share := range p.repPropagationSplit {
+					if !current.HasSenior() {
+						break
+					}
+
+					senior, err := tp.GetByCharacterId(*current.SeniorId())
+					if err != nil {
+						if errors.Is(err, ErrMemberNotFound) {
+							break
+						}
+						return err
+					}
+
+					// Apply the level penalty when the originating junior outlevels this senior
+					credited, lost := ApplyLevelPenalty(juniorLevel, senior.Level(), RepShare(amount, share))
+					if credited > 0 {
+						updatedSenior, err := tp.creditRep(buf, senior, credited, source, juniorId)
+						if err != nil {
+							return err
+						}
+						tp.putRepPenalized(buf, updatedSenior, lost)
+						recipients = append(recipients, updatedSenior)
+						senior = updatedSenior
+					}
+					current = senior
+				}
+				return nil
+			})
+			if err != nil {
+				return []FamilyMember{}, err
+			}
+
+			return recipients, nil
+		}
+	}
+}
+
+// putRepPenalized buffers a penalty event describing the rep a member lost to the level penalty
+func (p *ProcessorImpl) putRepPenalized(buf *message.Buffer, memberModel FamilyMember, lost uint32) {
+	if buf == nil || lost == 0 {
+		return
+	}
+	if putErr := buf.Put(familymsg.EnvEventTopicRep, RepPenalizedEventProvider(memberModel.World(), memberModel.CharacterId(), lost, RepPenaltyReasonJuniorOutlevelsSenior)); putErr != nil {
+		p.log.WithError(putErr).Error("Failed to add rep penalized event to buffer")
+	}
+}
+
+// creditRep adds rep to a loaded member, clamping to the daily cap, and buffers the resulting events
+func (p *ProcessorImpl) creditRep(buf *message.Buffer, memberModel FamilyMember, amount uint32, source string, sourceCharacterId uint32) (FamilyMember, error) {
+	characterId := memberModel.CharacterId()
+
+	// Clamp the award to the remaining daily headroom
+	credited := amount
+	if remaining := memberModel.RemainingDailyRep(); credited > remaining {
+		credited = remaining
+	}
+
+	updatedMember := memberModel
+	if credited > 0 {
+		var err error
+		updatedMember, err = memberModel.Builder().
+			AddRep(credited).
+			AddDailyRep(credited).
+			Touch().
+			Build()
+		if err != nil {
+			return FamilyMember{}, err
+		}
+
+		if updatedMember, err = p.saveRepChange(updatedMember, credited, ledger.DirectionCredit, source); err != nil {
+			return FamilyMember{}, err
+		}
+
+		// Add success event to buffer if provided
+		if buf != nil {
+			if putErr := buf.Put(familymsg.EnvEventTopicRep, RepGainedEventProvider(updatedMember.World(), characterId, credited, updatedMember.DailyRep(), source, sourceCharacterId)); putErr != nil {
+				p.log.WithError(putErr).Error("Failed to add rep gained event to buffer")
+			}
+		}
+	}
+
+	// Report the overflow which could not be credited today
+	if credited < amount {
+		p.log.WithFields(logrus.Fields{
+			"characterId": characterId,
+			"attempted":   amount,
+			"credited":    credited,
+		}).Info("Reputation award clamped by daily cap")
+
+		if buf != nil {
+			if putErr := buf.Put(familymsg.EnvEventTopicRep, RepCappedEventProvider(updatedMember.World(), characterId, amount, updatedMember.DailyRep(), source)); putErr != nil {
+				p.log.WithError(putErr).Error("Failed to add rep capped event to buffer")
+			}
+		}
+	}
+
+	return updatedMember, nil
+}
+
+// saveRepChange persists a member whose balance changed together with the ledger entry describing the change,
+// returning the member at its saved version
+func (p *ProcessorImpl) saveRepChange(updatedMember FamilyMember, amount uint32, direction string, reason string) (FamilyMember, error) {
+	entry, err := ledger.NewBuilder(updatedMember.TenantId(), updatedMember.CharacterId(), amount, direction).
+		SetReason(reason).
+		SetTransactionId(p.transactionId).
+		SetBalanceAfter(updatedMember.Rep()).
+		Build()
+	if err != nil {
+		return FamilyMember{}, err
+	}
+
+	var saved FamilyMember
+	err = p.db.Transaction(func(tx *gorm.DB) error {
+		entity, err := SaveMember(tx, p.log)(updatedMember)()
+		if err != nil {
+			return err
+		}
+		if saved, err = Make(entity); err != nil {
+			return err
+		}
+		_, err = ledger.Append(tx, p.log)(entry)()
+		return err
+	})
+	return saved, err
+}
+
+// DeductRep deducts reputation from a character
+func (p *ProcessorImpl) DeductRep(buf *message.Buffer) func(characterId uint32, amount uint32, reason string) model.Provider[FamilyMember] {
+	return func(characterId uint32, amount uint32, reason string) model.Provider[FamilyMember] {
+		return func() (FamilyMember, error) {
+			p.log.WithFields(logrus.Fields{
+				"characterId": characterId,
+				"amount":      amount,
+				"reason":      reason,
+			}).Info("Deducting reputation")
+
+			// Get the member
+			memberModel, err := p.GetByCharacterId(characterId)
+			if err != nil {
+				return FamilyMember{}, err
+			}
+
+			// Check if member has enough rep
+			if memberModel.Rep() < amount {
+				// Add error event to buffer if provided
+				if buf != nil {
+					if putErr := buf.Put(familymsg.EnvEventTopicErrors, RepErrorEventProvider(memberModel.World(), characterId, "DEDUCT_REP_FAILED", ErrInsufficientRep.Error(), amount)); putErr != nil {
+						p.log.WithError(putErr).Error("Failed to add rep error event to buffer")
+					}
+				}
+				return FamilyMember{}, ErrInsufficientRep
+			}
+
+			// Update member with deducted rep
+			updatedMember, err := memberModel.Builder().
+				SubtractRep(amount).
+				Touch().
+				Build()
+			if err != nil {
+				return FamilyMember{}, err
+			}
+
+			if updatedMember, err = p.saveRepChange(updatedMember, amount, ledger.DirectionDebit, reason); err != nil {
+				return FamilyMember{}, err
+			}
+
+			// Add success event to buffer if provided
+			if buf != nil {
+				if putErr := buf.Put(familymsg.EnvEventTopicRep, RepRedeemedEventProvider(updatedMember.World(), characterId, amount, reason)); putErr != nil {
+					p.log.WithError(putErr).Error("Failed to add rep redeemed event to buffer")
+				}
+			}
+
+			return updatedMember, nil
+		}
+	}
+}
+
+// RegisterActivity converts a junior activity into reputation and credits it through AwardRep
+func (p *ProcessorImpl) RegisterActivity(buf *message.Buffer) func(characterId uint32, activityType string, amount uint32) model.Provider[FamilyMember] {
+	return func(characterId uint32, activityType string, amount uint32) model.Provider[FamilyMember] {
+		return func() (FamilyMember, error) {
+			p.log.WithFields(logrus.Fields{
+				"characterId":  characterId,
+				"activityType": activityType,
+				"amount":       amount,
+			}).Info("Registering activity")
+
+			var result FamilyMember
+			err := p.db.Transaction(func(tx *gorm.DB) error {
+				tp := p.WithTransaction(tx)
+
+				memberModel, err := tp.GetByCharacterId(characterId)
+				if err != nil {
+					return err
+				}
+				result = memberModel
+
+				var repEarned uint32
+				switch activityType {
+				case ActivityTypeMobKill:
+					var carry uint32
+					repEarned, carry = CalculateKillRep(memberModel.PendingKills(), amount)
+
+					// Persist the kills which did not reach a full award
+					updatedMember, err := memberModel.Builder().
+						SetPendingKills(carry).
+						Touch().
+						Build()
+					if err != nil {
+						return err
+					}
+
+					if _, err := SaveMember(tx, p.log)(updatedMember)(); err != nil {
+						return err
+					}
+					result = updatedMember
+				case ActivityTypeExpedition:
+					repEarned = CalculateExpeditionRep(amount)
+				default:
+					return ErrInvalidActivityType
+				}
+
+				if repEarned == 0 {
+					return nil
+				}
+
+				result, err = tp.AwardRep(buf)(characterId, repEarned, activityType)()
+				return err
+			})
+
+			if err != nil {
+				if errors.Is(err, ErrInvalidActivityType) && buf != nil {
+					if putErr := buf.Put(familymsg.EnvEventTopicErrors, RepErrorEventProvider(0, characterId, "INVALID_ACTIVITY_TYPE", err.Error(), amount)); putErr != nil {
+						p.log.WithError(putErr).Error("Failed to add rep error event to buffer")
+					}
+				}
+				return FamilyMember{}, err
+			}
+
+			return result, nil
+		}
+	}
+}
+
+// ResetDailyRep resets daily reputation for all members of the tenant
+func (p *ProcessorImpl) ResetDailyRep(buf *message.Buffer) model.Provider[BatchResetResult] {
+	return func() (BatchResetResult, error) {
+		p.log.Info("Resetting daily reputation for all members")
+
+		result, err := BatchResetDailyRep(p.db, p.log)(p.t.Id())()
+		if err != nil {
+			_ = buf.Put(familymsg.EnvEventTopicErrors, RepErrorEventProvider(0, 0, "RESET_FAILED", err.Error(), 0))
+			return BatchResetResult{}, err
+		}
+
+		_ = buf.Put(familymsg.EnvEventTopicRep, RepResetEventProvider(0, 0, uint32(result.AffectedCount)))
+		return result, nil
+	}
+}
+
+// UpdateLevel records a character's new level on their family member
+func (p *ProcessorImpl) UpdateLevel(buf *message.Buffer) func(characterId uint32, level uint16) model.Provider[FamilyMember] {
+	return func(characterId uint32, level uint16) model.Provider[FamilyMember] {
+		return func() (FamilyMember, error) {
+			p.log.WithFields(logrus.Fields{
+				"characterId": characterId,
+				"level":       level,
+			}).Debug("Updating member level")
+
+			return p.updateMember(buf, characterId, func(b *Builder) *Builder {
+				return b.SetLevel(level)
+			})
+		}
+	}
+}
+
+// ChangeWorld records the world a character is now in on their family member
+func (p *ProcessorImpl) ChangeWorld(buf *message.Buffer) func(characterId uint32, worldId byte) model.Provider[FamilyMember] {
+	return func(characterId uint32, worldId byte) model.Provider[FamilyMember] {
+		return func() (FamilyMember, error) {
+			p.log.WithFields(logrus.Fields{
+				"characterId": characterId,
+				"worldId":     worldId,
+			}).Debug("Updating member world")
+
+			return p.updateMember(buf, characterId, func(b *Builder) *Builder {
+				return b.SetWorld(worldId)
+			})
+		}
+	}
+}
+
+// updateMember applies a change to a member's character details, saving it and emitting a member updated event when
+// the level or world actually changed
+func (p *ProcessorImpl) updateMember(buf *message.Buffer, characterId uint32, apply func(*Builder) *Builder) (FamilyMember, error) {
+	memberModel, err := p.GetByCharacterId(characterId)
+	if err != nil {
+		return FamilyMember{}, err
+	}
+
+	updatedMember, err := apply(memberModel.Builder()).Touch().Build()
+	if err != nil {
+		return FamilyMember{}, err
+	}
+	if updatedMember.Level() == memberModel.Level() && updatedMember.World() == memberModel.World() {
+		return memberModel, nil
+	}
+
+	entity, err := SaveMember(p.db, p.log)(updatedMember)()
+	if err != nil {
+		return FamilyMember{}, err
+	}
+	saved, err := Make(entity)
+	if err != nil {
+		return FamilyMember{}, err
+	}
+
+	if buf != nil {
+		if putErr := buf.Put(familymsg.EnvEventTopicStatus, MemberUpdatedEventProvider(saved.World(), characterId, saved.Level(), memberModel.Level(), memberModel.World())); putErr != nil {
+			p.log.WithError(putErr).Error("Failed to add member updated event to buffer")
+		}
+	}
+	return saved, nil
+}
+
+// AndEmit variants - combine business logic with event emission. Each runs at most once per transaction ID.
+
+// activityCommandType returns the command type under which an activity registration is recorded
+func activityCommandType(activityType string) string {
+	if activityType == ActivityTypeMobKill {
+		return familymsg.CommandTypeRegisterKillActivity
+	}
+	return familymsg.CommandTypeRegisterExpeditionActivity
+}
+
+// CreateMemberAndEmit enrolls a character and emits appropriate events
+func (p *ProcessorImpl) CreateMemberAndEmit(transactionId uuid.UUID, worldId byte, characterId uint32, level uint16) model.Provider[FamilyMember] {
+	return func() (FamilyMember, error) {
+		return idempotency.EmitOnce[FamilyMember](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeCreateMember)(func(tx *gorm.DB, buf *message.Buffer) (FamilyMember, error) {
+			return p.withTransaction(tx).CreateMember(buf)(worldId, characterId, level)()
+		})
+	}
+}
+
+// AddJuniorAndEmit adds a junior and emits appropriate events
+func (p *ProcessorImpl) AddJuniorAndEmit(transactionId uuid.UUID, worldId byte, seniorId uint32, seniorLevel uint16, juniorId uint32, juniorLevel uint16) model.Provider[FamilyMember] {
+	return func() (FamilyMember, error) {
+		return idempotency.EmitOnce[FamilyMember](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeAddJunior)(func(tx *gorm.DB, buf *message.Buffer) (FamilyMember, error) {
+			// Use base function which handles event emission
+			return p.withTransaction(tx).withTransactionId(transactionId).AddJunior(buf)(worldId, seniorId, seniorLevel, juniorId, juniorLevel)()
+		})
+	}
+}
+
+// RemoveMemberAndEmit removes a member and emits appropriate events
+func (p *ProcessorImpl) RemoveMemberAndEmit(transactionId uuid.UUID, characterId uint32, reason string) model.Provider[[]FamilyMember] {
+	return func() ([]FamilyMember, error) {
+		return idempotency.EmitOnce[[]FamilyMember](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeRemoveMember)(func(tx *gorm.DB, buf *message.Buffer) ([]FamilyMember, error) {
+			// Use base function which handles event emission
+			return p.withTransaction(tx).withTransactionId(transactionId).RemoveMember(buf)(characterId, reason)()
+		})
 	}
 }
 
 // BreakLinkAndEmit breaks a link and emits appropriate events
-func (p *ProcessorImpl) BreakLinkAndEmit(transactionId uuid.UUID, characterId uint32, reason string) model.Provider[[]FamilyMember] {
+func (p *ProcessorImpl) BreakLinkAndEmit(transactionId uuid.UUID, characterId uint32) model.Provider[[]FamilyMember] {
 	return func() ([]FamilyMember, error) {
-		return message.EmitWithResult[[]FamilyMember, struct{}](p.producer)(func(buf *message.Buffer) func(struct{}) ([]FamilyMember, error) {
-			return func(struct{}) ([]FamilyMember, error) {
-				// Use base function which handles event emission
-				return p.BreakLink(buf)(characterId, reason)()
-			}
-		})(struct{}{})
+		return idempotency.EmitOnce[[]FamilyMember](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeBreakLink)(func(tx *gorm.DB, buf *message.Buffer) ([]FamilyMember, error) {
+			// Use base function which handles event emission
+			return p.withTransaction(tx).withTransactionId(transactionId).BreakLink(buf)(characterId)()
+		})
+	}
+}
+
+// LeaveSeniorAndEmit leaves a junior's senior and emits appropriate events
+func (p *ProcessorImpl) LeaveSeniorAndEmit(transactionId uuid.UUID, characterId uint32) model.Provider[[]FamilyMember] {
+	return func() ([]FamilyMember, error) {
+		return idempotency.EmitOnce[[]FamilyMember](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeLeaveSenior)(func(tx *gorm.DB, buf *message.Buffer) ([]FamilyMember, error) {
+			return p.withTransaction(tx).withTransactionId(transactionId).LeaveSenior(buf)(characterId)()
+		})
+	}
+}
+
+// ExpelJuniorAndEmit expels a senior's junior and emits appropriate events
+func (p *ProcessorImpl) ExpelJuniorAndEmit(transactionId uuid.UUID, characterId uint32, juniorId uint32) model.Provider[[]FamilyMember] {
+	return func() ([]FamilyMember, error) {
+		return idempotency.EmitOnce[[]FamilyMember](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeExpelJunior)(func(tx *gorm.DB, buf *message.Buffer) ([]FamilyMember, error) {
+			return p.withTransaction(tx).withTransactionId(transactionId).ExpelJunior(buf)(characterId, juniorId)()
+		})
+	}
+}
+
+// ClearCooldownAndEmit clears a member's cooldown and emits appropriate events
+func (p *ProcessorImpl) ClearCooldownAndEmit(transactionId uuid.UUID, characterId uint32) model.Provider[FamilyMember] {
+	return func() (FamilyMember, error) {
+		return idempotency.EmitOnce[FamilyMember](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeClearCooldown)(func(tx *gorm.DB, buf *message.Buffer) (FamilyMember, error) {
+			return p.withTransaction(tx).ClearCooldown(buf)(characterId)()
+		})
+	}
+}
+
+// DissolveFamilyAndEmit dissolves a family and emits appropriate events
+func (p *ProcessorImpl) DissolveFamilyAndEmit(transactionId uuid.UUID, characterId uint32) model.Provider[[]FamilyMember] {
+	return func() ([]FamilyMember, error) {
+		return idempotency.EmitOnce[[]FamilyMember](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeDissolveFamily)(func(tx *gorm.DB, buf *message.Buffer) ([]FamilyMember, error) {
+			return p.withTransaction(tx).withTransactionId(transactionId).DissolveFamily(buf)(characterId)()
+		})
 	}
 }
 
 // AwardRepAndEmit awards reputation and emits appropriate events
 func (p *ProcessorImpl) AwardRepAndEmit(transactionId uuid.UUID, characterId uint32, amount uint32, source string) model.Provider[FamilyMember] {
 	return func() (FamilyMember, error) {
-		return message.EmitWithResult[FamilyMember, struct{}](p.producer)(func(buf *message.Buffer) func(struct{}) (FamilyMember, error) {
-			return func(struct{}) (FamilyMember, error) {
-				// Use base function which handles event emission
-				return p.AwardRep(buf)(characterId, amount, source)()
-			}
-		})(struct{}{})
+		return idempotency.EmitOnce[FamilyMember](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeAwardRep)(func(tx *gorm.DB, buf *message.Buffer) (FamilyMember, error) {
+			// Use base function which handles event emission
+			return p.withTransaction(tx).withTransactionId(transactionId).AwardRep(buf)(characterId, amount, source)()
+		})
+	}
+}
+
+// PropagateRepAndEmit propagates reputation to seniors and emits appropriate events
+func (p *ProcessorImpl) PropagateRepAndEmit(transactionId uuid.UUID, juniorId uint32, amount uint32, source string) model.Provider[[]FamilyMember] {
+	return func() ([]FamilyMember, error) {
+		return idempotency.EmitOnce[[]FamilyMember](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeAwardRep)(func(tx *gorm.DB, buf *message.Buffer) ([]FamilyMember, error) {
+			// Use base function which handles event emission
+			return p.withTransaction(tx).withTransactionId(transactionId).PropagateRep(buf)(juniorId, amount, source)()
+		})
 	}
 }
 
 // DeductRepAndEmit deducts reputation and emits appropriate events
 func (p *ProcessorImpl) DeductRepAndEmit(transactionId uuid.UUID, characterId uint32, amount uint32, reason string) model.Provider[FamilyMember] {
 	return func() (FamilyMember, error) {
-		return message.EmitWithResult[FamilyMember, struct{}](p.producer)(func(buf *message.Buffer) func(struct{}) (FamilyMember, error) {
-			return func(struct{}) (FamilyMember, error) {
-				// Use base function which handles event emission
-				return p.DeductRep(buf)(characterId, amount, reason)()
+		return idempotency.EmitOnce[FamilyMember](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeDeductRep)(func(tx *gorm.DB, buf *message.Buffer) (FamilyMember, error) {
+			// Use base function which handles event emission
+			return p.withTransaction(tx).withTransactionId(transactionId).DeductRep(buf)(characterId, amount, reason)()
+		})
+	}
+}
+
+// RegisterActivityAndEmit registers an activity and emits appropriate events
+func (p *ProcessorImpl) RegisterActivityAndEmit(transactionId uuid.UUID, characterId uint32, activityType string, amount uint32) model.Provider[FamilyMember] {
+	return func() (FamilyMember, error) {
+		return idempotency.EmitOnce[FamilyMember](p.log, p.ctx, p.db)(transactionId, activityCommandType(activityType))(func(tx *gorm.DB, buf *message.Buffer) (FamilyMember, error) {
+			// Use base function which handles event emission
+			return p.withTransaction(tx).withTransactionId(transactionId).RegisterActivity(buf)(characterId, activityType, amount)()
+		})
+	}
+}
+
+// UpdateLevelAndEmit records a level change and emits appropriate events
+func (p *ProcessorImpl) UpdateLevelAndEmit(transactionId uuid.UUID, characterId uint32, level uint16) model.Provider[FamilyMember] {
+	return func() (FamilyMember, error) {
+		return idempotency.EmitOnce[FamilyMember](p.log, p.ctx, p.db)(transactionId, charactermsg.StatusEventTypeLevelChanged)(func(tx *gorm.DB, buf *message.Buffer) (FamilyMember, error) {
+			return p.withTransaction(tx).withTransactionId(transactionId).UpdateLevel(buf)(characterId, level)()
+		})
+	}
+}
+
+// DeleteCharacterAndEmit cleans up after a deleted character and emits appropriate events
+func (p *ProcessorImpl) DeleteCharacterAndEmit(transactionId uuid.UUID, characterId uint32) model.Provider[[]FamilyMember] {
+	return func() ([]FamilyMember, error) {
+		return idempotency.EmitOnce[[]FamilyMember](p.log, p.ctx, p.db)(transactionId, charactermsg.StatusEventTypeDeleted)(func(tx *gorm.DB, buf *message.Buffer) ([]FamilyMember, error) {
+			return p.withTransaction(tx).withTransactionId(transactionId).DeleteCharacter(buf)(characterId)()
+		})
+	}
+}
+
+// RejectDeletedAndEmit fails with ErrCharacterDeleted when the character has been deleted, emitting a CHARACTER_DELETED
+// error event
+func (p *ProcessorImpl) RejectDeletedAndEmit(worldId byte, characterId uint32) error {
+	deleted, err := tombstone.ExistsProvider(p.t.Id(), characterId)(p.db)()
+	if err != nil || !deleted {
+		return err
+	}
+	return outbox.Emit(p.log, p.ctx, p.db)(func(tx *gorm.DB, buf *message.Buffer) error {
+		return p.withTransaction(tx).RejectDeleted(buf)(worldId, characterId)
+	})
+}
+
+// ChangeWorldAndEmit records a world change and emits appropriate events
+func (p *ProcessorImpl) ChangeWorldAndEmit(transactionId uuid.UUID, characterId uint32, worldId byte) model.Provider[FamilyMember] {
+	return func() (FamilyMember, error) {
+		return idempotency.EmitOnce[FamilyMember](p.log, p.ctx, p.db)(transactionId, charactermsg.StatusEventTypeChannelChanged)(func(tx *gorm.DB, buf *message.Buffer) (FamilyMember, error) {
+			return p.withTransaction(tx).withTransactionId(transactionId).ChangeWorld(buf)(characterId, worldId)()
+		})
+	}
+}
+
+// RenameFamily renames a family on behalf of its leader
+func (p *ProcessorImpl) RenameFamily(buf *message.Buffer) func(familyId uint32, characterId uint32, name string) model.Provider[Family] {
+	return func(familyId uint32, characterId uint32, name string) model.Provider[Family] {
+		return func() (Family, error) {
+			p.log.WithFields(logrus.Fields{
+				"familyId":    familyId,
+				"characterId": characterId,
+				"name":        name,
+			}).Info("Renaming family")
+
+			previous, result, err := p.updateFamily(buf, familyId, characterId, "RENAME_FAMILY_FAILED", func(b *FamilyBuilder) (*FamilyBuilder, error) {
+				return b.SetName(name), nil
+			})
+			if err != nil {
+				return Family{}, err
+			}
+
+			if buf != nil {
+				if putErr := buf.Put(familymsg.EnvEventTopicStatus, FamilyRenamedEventProvider(p.worldOf(characterId), characterId, result.Id(), result.Name(), previous.Name())); putErr != nil {
+					p.log.WithError(putErr).Error("Failed to add family renamed event to buffer")
+				}
+			}
+
+			return result, nil
+		}
+	}
+}
+
+// SetPrecept sets the family motto on behalf of its leader
+func (p *ProcessorImpl) SetPrecept(buf *message.Buffer) func(familyId uint32, characterId uint32, precept string) model.Provider[Family] {
+	return func(familyId uint32, characterId uint32, precept string) model.Provider[Family] {
+		return func() (Family, error) {
+			p.log.WithFields(logrus.Fields{
+				"familyId":    familyId,
+				"characterId": characterId,
+			}).Info("Setting family precept")
+
+			_, result, err := p.updateFamily(buf, familyId, characterId, "SET_PRECEPT_FAILED", func(b *FamilyBuilder) (*FamilyBuilder, error) {
+				if err := ValidateFamilyText(precept, PreceptMaxLength()); err != nil {
+					return nil, err
+				}
+				if err := p.textFilter.Check(precept); err != nil {
+					return nil, err
+				}
+				return b.SetPrecept(precept), nil
+			})
+			if err != nil {
+				return Family{}, err
+			}
+
+			if buf != nil {
+				if putErr := buf.Put(familymsg.EnvEventTopicStatus, FamilyTextUpdatedEventProvider(familymsg.EventTypePreceptUpdated, p.worldOf(characterId), characterId, result.Id(), result.Precept())); putErr != nil {
+					p.log.WithError(putErr).Error("Failed to add precept updated event to buffer")
+				}
+			}
+
+			return result, nil
+		}
+	}
+}
+
+// SetNotice sets the family notice on behalf of its leader
+func (p *ProcessorImpl) SetNotice(buf *message.Buffer) func(familyId uint32, characterId uint32, notice string) model.Provider[Family] {
+	return func(familyId uint32, characterId uint32, notice string) model.Provider[Family] {
+		return func() (Family, error) {
+			p.log.WithFields(logrus.Fields{
+				"familyId":    familyId,
+				"characterId": characterId,
+			}).Info("Setting family notice")
+
+			_, result, err := p.updateFamily(buf, familyId, characterId, "SET_NOTICE_FAILED", func(b *FamilyBuilder) (*FamilyBuilder, error) {
+				if err := ValidateFamilyText(notice, NoticeMaxLength()); err != nil {
+					return nil, err
+				}
+				if err := p.textFilter.Check(notice); err != nil {
+					return nil, err
+				}
+				return b.SetNotice(notice), nil
+			})
+			if err != nil {
+				return Family{}, err
+			}
+
+			if buf != nil {
+				if putErr := buf.Put(familymsg.EnvEventTopicStatus, FamilyTextUpdatedEventProvider(familymsg.EventTypeNoticeUpdated, p.worldOf(characterId), characterId, result.Id(), result.Notice())); putErr != nil {
+					p.log.WithError(putErr).Error("Failed to add notice updated event to buffer")
+				}
+			}
+
+			return result, nil
+		}
+	}
+}
+
+// updateFamily applies a leader-only change to a family within a transaction, returning the family before and after
+func (p *ProcessorImpl) updateFamily(buf *message.Buffer, familyId uint32, characterId uint32, failureCode string, apply func(*FamilyBuilder) (*FamilyBuilder, error)) (Family, Family, error) {
+	var previous, result Family
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		previous, err = p.withTransaction(tx).GetFamilyById(familyId)
+		if err != nil {
+			return err
+		}
+		if !previous.IsLeader(characterId) {
+			return ErrNotFamilyLeader
+		}
+
+		b, err := apply(previous.Builder())
+		if err != nil {
+			return err
+		}
+		updated, err := b.Touch().Build()
+		if err != nil {
+			return err
+		}
+
+		result, err = model.Map(MakeFamily)(UpdateFamily(tx, p.log)(updated))()
+		return err
+	})
+
+	if err != nil {
+		if buf != nil {
+			errorCode := failureCode
+			switch {
+			case errors.Is(err, ErrFamilyNotFound):
+				errorCode = "FAMILY_NOT_FOUND"
+			case errors.Is(err, ErrNotFamilyLeader):
+				errorCode = "NOT_FAMILY_LEADER"
+			case errors.Is(err, ErrInvalidFamilyName):
+				errorCode = "INVALID_FAMILY_NAME"
+			case errors.Is(err, ErrFamilyTextTooLong):
+				errorCode = "TEXT_TOO_LONG"
+			case errors.Is(err, textfilter.ErrTextRejected):
+				errorCode = "TEXT_REJECTED"
+			}
+			if putErr := buf.Put(familymsg.EnvEventTopicErrors, FamilyErrorEventProvider(p.worldOf(characterId), characterId, familyId, errorCode, err.Error())); putErr != nil {
+				p.log.WithError(putErr).Error("Failed to add family error event to buffer")
 			}
-		})(struct{}{})
+		}
+		return Family{}, Family{}, err
+	}
+	return previous, result, nil
+}
+
+// worldOf returns the world of a member for event addressing, or 0 when the member cannot be loaded
+func (p *ProcessorImpl) worldOf(characterId uint32) byte {
+	if member, err := p.GetByCharacterId(characterId); err == nil {
+		return member.World()
+	}
+	return 0
+}
+
+// RenameFamilyAndEmit renames a family and emits appropriate events
+func (p *ProcessorImpl) RenameFamilyAndEmit(transactionId uuid.UUID, familyId uint32, characterId uint32, name string) model.Provider[Family] {
+	return func() (Family, error) {
+		return idempotency.EmitOnce[Family](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeRenameFamily)(func(tx *gorm.DB, buf *message.Buffer) (Family, error) {
+			// Use base function which handles event emission
+			return p.withTransaction(tx).withTransactionId(transactionId).RenameFamily(buf)(familyId, characterId, name)()
+		})
+	}
+}
+
+// SetPreceptAndEmit sets the family precept and emits appropriate events
+func (p *ProcessorImpl) SetPreceptAndEmit(transactionId uuid.UUID, familyId uint32, characterId uint32, precept string) model.Provider[Family] {
+	return func() (Family, error) {
+		return idempotency.EmitOnce[Family](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeSetPrecept)(func(tx *gorm.DB, buf *message.Buffer) (Family, error) {
+			// Use base function which handles event emission
+			return p.withTransaction(tx).withTransactionId(transactionId).SetPrecept(buf)(familyId, characterId, precept)()
+		})
+	}
+}
+
+// SetNoticeAndEmit sets the family notice and emits appropriate events
+func (p *ProcessorImpl) SetNoticeAndEmit(transactionId uuid.UUID, familyId uint32, characterId uint32, notice string) model.Provider[Family] {
+	return func() (Family, error) {
+		return idempotency.EmitOnce[Family](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeSetNotice)(func(tx *gorm.DB, buf *message.Buffer) (Family, error) {
+			// Use base function which handles event emission
+			return p.withTransaction(tx).withTransactionId(transactionId).SetNotice(buf)(familyId, characterId, notice)()
+		})
 	}
 }
 
 func (p *ProcessorImpl) GetFamilyTree(characterId uint32) ([]FamilyMember, error) {
-	return model.SliceMap(Make)(GetFamilyTreeProvider(characterId)(p.db))(model.ParallelMap())()
+	return model.SliceMap(Make)(GetFamilyTreeProvider(p.t.Id(), characterId)(p.db))(model.ParallelMap())()
 }
 
+// GetPedigree resolves the root ancestor of a character and returns every descendant up to maxDepth generations
+func (p *ProcessorImpl) GetPedigree(characterId uint32, maxDepth uint32) (Pedigree, error) {
+	root, err := GetRootAncestorProvider(p.t.Id(), characterId)(p.db)()
+	if err != nil {
+		return Pedigree{}, err
+	}
+
+	entities, err := GetDescendantsProvider(p.t.Id(), root.CharacterId, maxDepth)(p.db)()
+	if err != nil {
+		return Pedigree{}, err
+	}
+	return MakePedigree(root.CharacterId, entities)
+}
+
+// GetByCharacterId returns a character's family member, failing with ErrCharacterDeleted for a deleted character
 func (p *ProcessorImpl) GetByCharacterId(characterId uint32) (FamilyMember, error) {
-	return model.Map(Make)(GetByIdProvider(characterId)(p.db))()
+	m, err := model.Map(Make)(GetByCharacterIdProvider(p.t.Id(), characterId)(p.db))()
+	if errors.Is(err, ErrMemberNotFound) {
+		deleted, terr := tombstone.ExistsProvider(p.t.Id(), characterId)(p.db)()
+		if terr != nil {
+			return FamilyMember{}, terr
+		}
+		if deleted {
+			return FamilyMember{}, ErrCharacterDeleted
+		}
+	}
+	return m, err
+}
+
+func (p *ProcessorImpl) GetFamilyById(familyId uint32) (Family, error) {
+	return model.Map(MakeFamily)(GetFamilyByIdProvider(p.t.Id(), familyId)(p.db))()
+}
+
+// GetFamilyByMemberId returns the family a character currently belongs to
+func (p *ProcessorImpl) GetFamilyByMemberId(characterId uint32) (Family, error) {
+	member, err := p.GetByCharacterId(characterId)
+	if err != nil {
+		return Family{}, err
+	}
+	if member.FamilyId() == nil {
+		return Family{}, ErrFamilyNotFound
+	}
+	return p.GetFamilyById(*member.FamilyId())
 }
@@ -0,0 +1,1130 @@
+package family
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"atlas-family/database"
+	"atlas-family/idempotency"
+	"atlas-family/kafka/message"
+	familymsg "atlas-family/kafka/message/family"
+	"atlas-family/ledger"
+	"atlas-family/location"
+	"atlas-family/outbox"
+	"atlas-family/rules"
+	"atlas-family/textfilter"
+
+	tenant "github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// newTestProcessor creates a processor bound to a tenant context and the given database
+func newTestProcessor(t *testing.T, db *gorm.DB, tenantId uuid.UUID) Processor {
+	t.Helper()
+	tm, err := tenant.Create(tenantId, "GMS", 83, 1)
+	if err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+	p := NewProcessor(l, tenant.WithContext(context.Background(), tm), db).(*ProcessorImpl)
+	p.locations = location.NewMemoryProvider().SetFallback(location.NewModel(1, 100000000))
+	return p
+}
+
+// seedChain inserts a single line of descent of the given length, returning the character IDs from root to leaf
+func seedChain(t *testing.T, db *gorm.DB, tenantId uuid.UUID, rootId uint32, length int) []uint32 {
+	t.Helper()
+	ids := make([]uint32, 0, length)
+	for i := 0; i < length; i++ {
+		ids = append(ids, rootId+uint32(i))
+	}
+	for i, characterId := range ids {
+		var seniorId *uint32
+		if i > 0 {
+			seniorId = ptr(ids[i-1])
+		}
+		var juniorIds []uint32
+		if i < len(ids)-1 {
+			juniorIds = []uint32{ids[i+1]}
+		}
+		seedMember(t, db, tenantId, characterId, seniorId, juniorIds...)
+	}
+	return ids
+}
+
+func TestGetAncestryProvider_DeepChain(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	chain := seedChain(t, db, tenantId, 1000, 40)
+
+	ancestry, err := GetAncestryProvider(tenantId, chain[len(chain)-1])(db)()
+	if err != nil {
+		t.Fatalf("Failed to get ancestry: %v", err)
+	}
+	if len(ancestry) != len(chain)-1 {
+		t.Fatalf("Expected %d ancestors, got %d", len(chain)-1, len(ancestry))
+	}
+	if ancestry[0] != chain[len(chain)-2] || ancestry[len(ancestry)-1] != chain[0] {
+		t.Errorf("Expected ancestry ordered nearest first, got %v", ancestry)
+	}
+}
+
+func TestAddJunior_RejectsCycleInDeepChain(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	chain := seedChain(t, db, tenantId, 1000, 40)
+	root, leaf := chain[0], chain[len(chain)-1]
+
+	_, err := newTestProcessor(t, db, tenantId).AddJunior(nil)(1, leaf, 50, root, 50)()
+	if !errors.Is(err, ErrCycleDetected) {
+		t.Fatalf("Expected ErrCycleDetected, got %v", err)
+	}
+
+	// Neither side of the rejected link may have been modified
+	leafEntity, err := GetByCharacterIdProvider(tenantId, leaf)(db)()
+	if err != nil {
+		t.Fatalf("Failed to load leaf: %v", err)
+	}
+	if len(leafEntity.JuniorIds) != 0 {
+		t.Errorf("Expected leaf to have no juniors, got %v", leafEntity.JuniorIds)
+	}
+	rootEntity, err := GetByCharacterIdProvider(tenantId, root)(db)()
+	if err != nil {
+		t.Fatalf("Failed to load root: %v", err)
+	}
+	if rootEntity.SeniorId != nil {
+		t.Errorf("Expected root to have no senior, got %d", *rootEntity.SeniorId)
+	}
+}
+
+func TestAddJunior_RejectsCycleBeyondPedigreeDepth(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	chain := seedChain(t, db, tenantId, 1000, MaxPedigreeDepth+10)
+	root, leaf := chain[0], chain[len(chain)-1]
+
+	ancestry, err := GetAncestryProvider(tenantId, leaf)(db)()
+	if err != nil {
+		t.Fatalf("Failed to get ancestry: %v", err)
+	}
+	if len(ancestry) != len(chain)-1 {
+		t.Fatalf("Expected %d ancestors, got %d", len(chain)-1, len(ancestry))
+	}
+
+	_, err = newTestProcessor(t, db, tenantId).AddJunior(nil)(1, leaf, 50, root, 50)()
+	if !errors.Is(err, ErrCycleDetected) {
+		t.Fatalf("Expected ErrCycleDetected, got %v", err)
+	}
+}
+
+func TestAddJunior_AllowsLinkingSeparateChains(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	first := seedChain(t, db, tenantId, 1000, 40)
+	second := seedChain(t, db, tenantId, 5000, 10)
+
+	// The root of one family joining below the leaf of another is not a cycle
+	_, err := newTestProcessor(t, db, tenantId).AddJunior(nil)(1, first[len(first)-1], 50, second[0], 50)()
+	if err != nil {
+		t.Fatalf("Expected link between separate chains to succeed, got %v", err)
+	}
+
+	junior, err := GetByCharacterIdProvider(tenantId, second[0])(db)()
+	if err != nil {
+		t.Fatalf("Failed to load junior: %v", err)
+	}
+	if junior.SeniorId == nil || *junior.SeniorId != first[len(first)-1] {
+		t.Errorf("Expected junior to be linked to %d", first[len(first)-1])
+	}
+
+	// Once merged, the former root of the first chain cannot join below the second chain's leaf
+	_, err = newTestProcessor(t, db, tenantId).AddJunior(nil)(1, second[len(second)-1], 50, first[0], 50)()
+	if !errors.Is(err, ErrCycleDetected) {
+		t.Errorf("Expected ErrCycleDetected after merging chains, got %v", err)
+	}
+}
+
+// assertFamily verifies the family led by leaderId has the expected member count and is referenced by every member
+func assertFamily(t *testing.T, db *gorm.DB, tenantId uuid.UUID, leaderId uint32, memberIds ...uint32) FamilyEntity {
+	t.Helper()
+	f, err := GetFamilyByLeaderIdProvider(tenantId, leaderId)(db)()
+	if err != nil {
+		t.Fatalf("Failed to load family led by %d: %v", leaderId, err)
+	}
+	if f.MemberCount != uint32(len(memberIds)) {
+		t.Errorf("Expected family led by %d to have %d members, got %d", leaderId, len(memberIds), f.MemberCount)
+	}
+	for _, characterId := range memberIds {
+		member, err := GetByCharacterIdProvider(tenantId, characterId)(db)()
+		if err != nil {
+			t.Fatalf("Failed to load member %d: %v", characterId, err)
+		}
+		if member.FamilyId == nil || *member.FamilyId != f.ID {
+			t.Errorf("Expected member %d to belong to family %d, got %v", characterId, f.ID, member.FamilyId)
+		}
+	}
+	return f
+}
+
+func TestFamily_MaintainedAcrossLinkChanges(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	for _, characterId := range []uint32{1000, 2000, 3000, 4000} {
+		seedMember(t, db, tenantId, characterId, nil)
+	}
+	p := newTestProcessor(t, db, tenantId)
+
+	if _, err := p.AddJunior(nil)(1, 1000, 50, 2000, 50)(); err != nil {
+		t.Fatalf("Failed to add junior: %v", err)
+	}
+	assertFamily(t, db, tenantId, 1000, 1000, 2000)
+
+	// A separate family merges into the first when its leader becomes a junior
+	if _, err := p.AddJunior(nil)(1, 3000, 50, 4000, 50)(); err != nil {
+		t.Fatalf("Failed to add junior: %v", err)
+	}
+	assertFamily(t, db, tenantId, 3000, 3000, 4000)
+	if _, err := p.AddJunior(nil)(1, 2000, 50, 3000, 50)(); err != nil {
+		t.Fatalf("Failed to add junior: %v", err)
+	}
+	assertFamily(t, db, tenantId, 1000, 1000, 2000, 3000, 4000)
+	if _, err := GetFamilyByLeaderIdProvider(tenantId, 3000)(db)(); !errors.Is(err, ErrFamilyNotFound) {
+		t.Errorf("Expected merged family to be dissolved, got %v", err)
+	}
+
+	// Breaking a link splits the subtree into its own family
+	if _, err := p.BreakLink(nil)(3000)(); err != nil {
+		t.Fatalf("Failed to break link: %v", err)
+	}
+	assertFamily(t, db, tenantId, 1000, 1000, 2000)
+	if _, err := GetFamilyByLeaderIdProvider(tenantId, 3000)(db)(); !errors.Is(err, ErrFamilyNotFound) {
+		t.Errorf("Expected lone members to have no family, got %v", err)
+	}
+
+	// Removing the last junior leaves the leader without a family
+	if _, err := p.RemoveMember(nil)(2000, "test")(); err != nil {
+		t.Fatalf("Failed to remove member: %v", err)
+	}
+	if _, err := GetFamilyByLeaderIdProvider(tenantId, 1000)(db)(); !errors.Is(err, ErrFamilyNotFound) {
+		t.Errorf("Expected family to be dissolved, got %v", err)
+	}
+	if _, err := p.GetFamilyByMemberId(1000); !errors.Is(err, ErrFamilyNotFound) {
+		t.Errorf("Expected leader to have no family, got %v", err)
+	}
+}
+
+func TestRenameFamily_LeaderOnly(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	seedMember(t, db, tenantId, 1000, nil)
+	seedMember(t, db, tenantId, 2000, nil)
+	p := newTestProcessor(t, db, tenantId)
+
+	if _, err := p.AddJunior(nil)(1, 1000, 50, 2000, 50)(); err != nil {
+		t.Fatalf("Failed to add junior: %v", err)
+	}
+	f, err := p.GetFamilyByMemberId(2000)
+	if err != nil {
+		t.Fatalf("Failed to get family by member: %v", err)
+	}
+
+	buf := message.NewBuffer()
+	if _, err := p.RenameFamily(buf)(f.Id(), 2000, "Juniors")(); !errors.Is(err, ErrNotFamilyLeader) {
+		t.Errorf("Expected ErrNotFamilyLeader, got %v", err)
+	}
+	ms := buf.GetAll()[familymsg.EnvEventTopicErrors]
+	if len(ms) != 1 || !strings.Contains(string(ms[0].Value), familymsg.EventTypeFamilyError) || !strings.Contains(string(ms[0].Value), "NOT_FAMILY_LEADER") {
+		t.Error("Expected a FAMILY_ERROR event carrying NOT_FAMILY_LEADER")
+	}
+	if _, err := p.RenameFamily(nil)(f.Id(), 1000, "ThisNameIsTooLong")(); !errors.Is(err, ErrInvalidFamilyName) {
+		t.Errorf("Expected ErrInvalidFamilyName, got %v", err)
+	}
+
+	renamed, err := p.RenameFamily(nil)(f.Id(), 1000, "Atlas")()
+	if err != nil {
+		t.Fatalf("Failed to rename family: %v", err)
+	}
+	if renamed.Name() != "Atlas" || renamed.LeaderId() != 1000 || renamed.MemberCount() != 2 {
+		t.Errorf("Unexpected family after rename: %+v", renamed)
+	}
+}
+
+func TestSetPrecept_LeaderOnlyAndModerated(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	seedMember(t, db, tenantId, 1000, nil)
+	seedMember(t, db, tenantId, 2000, nil)
+	p := newTestProcessor(t, db, tenantId).(*ProcessorImpl)
+	p.textFilter = textfilter.NewWordList("darn")
+
+	if _, err := p.AddJunior(nil)(1, 1000, 50, 2000, 50)(); err != nil {
+		t.Fatalf("Failed to add junior: %v", err)
+	}
+	f, err := p.GetFamilyByMemberId(1000)
+	if err != nil {
+		t.Fatalf("Failed to get family by member: %v", err)
+	}
+
+	if _, err := p.SetPrecept(nil)(f.Id(), 2000, "Be kind")(); !errors.Is(err, ErrNotFamilyLeader) {
+		t.Errorf("Expected ErrNotFamilyLeader, got %v", err)
+	}
+	if _, err := p.SetPrecept(nil)(f.Id(), 1000, strings.Repeat("a", int(PreceptMaxLength())+1))(); !errors.Is(err, ErrFamilyTextTooLong) {
+		t.Errorf("Expected ErrFamilyTextTooLong, got %v", err)
+	}
+	if _, err := p.SetNotice(nil)(f.Id(), 1000, "darn this grind")(); !errors.Is(err, textfilter.ErrTextRejected) {
+		t.Errorf("Expected ErrTextRejected, got %v", err)
+	}
+
+	if _, err := p.SetPrecept(nil)(f.Id(), 1000, "Be kind")(); err != nil {
+		t.Fatalf("Failed to set precept: %v", err)
+	}
+	updated, err := p.SetNotice(nil)(f.Id(), 1000, "Boss run at 8")()
+	if err != nil {
+		t.Fatalf("Failed to set notice: %v", err)
+	}
+	if updated.Precept() != "Be kind" || updated.Notice() != "Boss run at 8" {
+		t.Errorf("Unexpected family text, precept %q notice %q", updated.Precept(), updated.Notice())
+	}
+}
+
+func TestRepChanges_RecordedInLedger(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	seedMember(t, db, tenantId, 1000, nil)
+	p := newTestProcessor(t, db, tenantId)
+	transactionId := uuid.New()
+
+	if _, err := p.WithTransactionId(transactionId).AwardRep(nil)(1000, 300, "QUEST")(); err != nil {
+		t.Fatalf("Failed to award rep: %v", err)
+	}
+	if _, err := p.DeductRep(nil)(1000, 120, "EXP_1_5X")(); err != nil {
+		t.Fatalf("Failed to deduct rep: %v", err)
+	}
+	if _, err := p.DeductRep(nil)(1000, 500, "EXP_2X")(); !errors.Is(err, ErrInsufficientRep) {
+		t.Fatalf("Expected ErrInsufficientRep, got %v", err)
+	}
+
+	var entries []ledger.Entity
+	if err := db.Order("id").Find(&entries).Error; err != nil {
+		t.Fatalf("Failed to load ledger: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 ledger entries, got %d", len(entries))
+	}
+	if entries[0].Direction != ledger.DirectionCredit || entries[0].Amount != 300 || entries[0].BalanceAfter != 300 || entries[0].Reason != "QUEST" || entries[0].TransactionId != transactionId {
+		t.Errorf("Unexpected credit entry: %+v", entries[0])
+	}
+	if entries[1].Direction != ledger.DirectionDebit || entries[1].Amount != 120 || entries[1].BalanceAfter != 180 || entries[1].Reason != "EXP_1_5X" {
+		t.Errorf("Unexpected debit entry: %+v", entries[1])
+	}
+}
+
+// stagedMessages returns the messages staged in the outbox for a topic, in staging order
+func stagedMessages(t *testing.T, db *gorm.DB, topic string) []outbox.Entity {
+	t.Helper()
+	var entities []outbox.Entity
+	if err := db.Where("topic = ?", topic).Order("id").Find(&entities).Error; err != nil {
+		t.Fatalf("Failed to load outbox: %v", err)
+	}
+	return entities
+}
+
+func TestAwardRepAndEmit_DuplicateTransactionAppliedOnce(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	seedMember(t, db, tenantId, 1000, nil)
+	p := newTestProcessor(t, db, tenantId)
+	transactionId := uuid.New()
+
+	if _, err := p.AwardRepAndEmit(transactionId, 1000, 300, "QUEST")(); err != nil {
+		t.Fatalf("Failed to award rep: %v", err)
+	}
+	if _, err := p.AwardRepAndEmit(transactionId, 1000, 300, "QUEST")(); !errors.Is(err, idempotency.ErrDuplicateCommand) {
+		t.Fatalf("Expected ErrDuplicateCommand, got %v", err)
+	}
+
+	member, err := p.GetByCharacterId(1000)
+	if err != nil {
+		t.Fatalf("Failed to get member: %v", err)
+	}
+	if member.Rep() != 300 {
+		t.Errorf("Expected rep 300 after duplicate delivery, got %d", member.Rep())
+	}
+	if staged := stagedMessages(t, db, familymsg.EnvEventTopicRep); len(staged) != 1 {
+		t.Errorf("Expected 1 rep event, got %d", len(staged))
+	}
+
+	// A distinct transaction is applied as usual
+	if _, err := p.AwardRepAndEmit(uuid.New(), 1000, 200, "QUEST")(); err != nil {
+		t.Fatalf("Failed to award rep: %v", err)
+	}
+	if member, _ = p.GetByCharacterId(1000); member.Rep() != 500 {
+		t.Errorf("Expected rep 500, got %d", member.Rep())
+	}
+}
+
+func TestDeductRepAndEmit_RejectedCommandRecorded(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	seedMember(t, db, tenantId, 1000, nil)
+	p := newTestProcessor(t, db, tenantId)
+	transactionId := uuid.New()
+
+	if _, err := p.DeductRepAndEmit(transactionId, 1000, 100, "EXP_2X")(); !errors.Is(err, ErrInsufficientRep) {
+		t.Fatalf("Expected ErrInsufficientRep, got %v", err)
+	}
+	if _, err := p.DeductRepAndEmit(transactionId, 1000, 100, "EXP_2X")(); !errors.Is(err, idempotency.ErrDuplicateCommand) {
+		t.Fatalf("Expected ErrDuplicateCommand, got %v", err)
+	}
+
+	records, err := idempotency.GetByTransactionIdProvider(tenantId, transactionId)(db)()
+	if err != nil {
+		t.Fatalf("Failed to load processed commands: %v", err)
+	}
+	if len(records) != 1 || records[0].Outcome != idempotency.OutcomeFailed || records[0].CommandType != familymsg.CommandTypeDeductRep {
+		t.Fatalf("Unexpected processed commands: %+v", records)
+	}
+	if len(records[0].Events) != 1 || records[0].Events[0].Topic != familymsg.EnvEventTopicErrors {
+		t.Errorf("Expected the error event to be stored, got %+v", records[0].Events)
+	}
+	if staged := stagedMessages(t, db, familymsg.EnvEventTopicErrors); len(staged) != 1 {
+		t.Errorf("Expected 1 error event, got %d", len(staged))
+	}
+	if staged := stagedMessages(t, db, familymsg.EnvEventTopicRep); len(staged) != 0 {
+		t.Errorf("Expected no rep events for a rejected command, got %d", len(staged))
+	}
+}
+
+func TestSaveMember_RejectsStaleVersion(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	seedMember(t, db, tenantId, 1000, nil)
+	p := newTestProcessor(t, db, tenantId)
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+
+	loaded, err := p.GetByCharacterId(1000)
+	if err != nil {
+		t.Fatalf("Failed to get member: %v", err)
+	}
+
+	first, _ := loaded.Builder().AddRep(100).Build()
+	saved, err := SaveMember(db, l)(first)()
+	if err != nil {
+		t.Fatalf("Failed to save member: %v", err)
+	}
+	if saved.Version != loaded.Version()+1 {
+		t.Errorf("Expected version %d, got %d", loaded.Version()+1, saved.Version)
+	}
+
+	// A second writer which read the same version must not overwrite the first
+	second, _ := loaded.Builder().AddRep(200).Build()
+	_, err = SaveMember(db, l)(second)()
+	var conflict ConflictError
+	if !errors.As(err, &conflict) || !errors.Is(err, database.ErrConflict) {
+		t.Fatalf("Expected a ConflictError, got %v", err)
+	}
+	if conflict.CharacterId != 1000 || conflict.Version != loaded.Version() {
+		t.Errorf("Unexpected conflict: %+v", conflict)
+	}
+
+	member, _ := p.GetByCharacterId(1000)
+	if member.Rep() != 100 || member.Version() != saved.Version {
+		t.Errorf("Expected rep 100 at version %d, got rep %d at version %d", saved.Version, member.Rep(), member.Version())
+	}
+}
+
+func TestBreakLink_SavesMemberTwiceWithinTransaction(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+
+	// 1000 -> 2000 -> 3000; breaking 2000 clears both its senior and its juniors
+	seedMember(t, db, tenantId, 1000, nil, 2000)
+	seedMember(t, db, tenantId, 2000, ptr(1000), 3000)
+	seedMember(t, db, tenantId, 3000, ptr(2000))
+	p := newTestProcessor(t, db, tenantId)
+
+	if _, err := p.BreakLink(nil)(2000)(); err != nil {
+		t.Fatalf("Failed to break link: %v", err)
+	}
+
+	member, err := p.GetByCharacterId(2000)
+	if err != nil {
+		t.Fatalf("Failed to get member: %v", err)
+	}
+	if member.HasSenior() || member.HasJuniors() {
+		t.Errorf("Expected member to be unlinked, got senior %v and juniors %v", member.SeniorId(), member.JuniorIds())
+	}
+}
+
+func TestProcessor_AppliesTenantRules(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	previous := rules.GetRegistry()
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+	rules.SetRegistry(rules.NewRegistry(l, rules.NewMemorySource([]byte(`{"tenants": {"`+tenantA.String()+`": {"maxJuniors": 3, "dailyRepCap": 150}}}`))))
+	t.Cleanup(func() { rules.SetRegistry(previous) })
+
+	for _, tenantId := range []uuid.UUID{tenantA, tenantB} {
+		seedMember(t, db, tenantId, 1000, nil, 2000, 3000)
+		seedMember(t, db, tenantId, 2000, ptr(1000))
+		seedMember(t, db, tenantId, 3000, ptr(1000))
+		seedMember(t, db, tenantId, 4000, nil)
+	}
+
+	if _, err := newTestProcessor(t, db, tenantA).AddJunior(nil)(1, 1000, 50, 4000, 50)(); err != nil {
+		t.Errorf("Expected a third junior to be allowed in tenant A, got %v", err)
+	}
+	if _, err := newTestProcessor(t, db, tenantB).AddJunior(nil)(1, 1000, 50, 4000, 50)(); !errors.Is(err, ErrSeniorHasTooManyJuniors) {
+		t.Errorf("Expected ErrSeniorHasTooManyJuniors in tenant B, got %v", err)
+	}
+
+	member, err := newTestProcessor(t, db, tenantA).AwardRep(nil)(2000, 200, "QUEST")()
+	if err != nil {
+		t.Fatalf("Failed to award rep in tenant A: %v", err)
+	}
+	if member.Rep() != 150 || !member.IsRepCapReached() {
+		t.Errorf("Expected award to be clamped to the tenant A cap of 150, got %d", member.Rep())
+	}
+	member, err = newTestProcessor(t, db, tenantB).AwardRep(nil)(2000, 200, "QUEST")()
+	if err != nil {
+		t.Fatalf("Failed to award rep in tenant B: %v", err)
+	}
+	if member.Rep() != 200 {
+		t.Errorf("Expected full award under the default cap in tenant B, got %d", member.Rep())
+	}
+}
+
+func TestUpdateMember_EmitsMemberUpdated(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	seedMember(t, db, tenantId, 1000, nil)
+	p := newTestProcessor(t, db, tenantId)
+
+	member, err := p.UpdateLevelAndEmit(uuid.New(), 1000, 51)()
+	if err != nil {
+		t.Fatalf("Failed to update level: %v", err)
+	}
+	if member.Level() != 51 || member.World() != 1 {
+		t.Errorf("Expected level 51 in world 1, got level %d in world %d", member.Level(), member.World())
+	}
+
+	member, err = p.ChangeWorldAndEmit(uuid.New(), 1000, 2)()
+	if err != nil {
+		t.Fatalf("Failed to change world: %v", err)
+	}
+	if member.Level() != 51 || member.World() != 2 {
+		t.Errorf("Expected level 51 in world 2, got level %d in world %d", member.Level(), member.World())
+	}
+
+	// A channel change within the same world leaves the member untouched
+	if _, err = p.ChangeWorldAndEmit(uuid.New(), 1000, 2)(); err != nil {
+		t.Fatalf("Failed to change world: %v", err)
+	}
+	if staged := stagedMessages(t, db, familymsg.EnvEventTopicStatus); len(staged) != 2 {
+		t.Errorf("Expected 2 member updated events, got %d", len(staged))
+	}
+
+	if _, err = p.UpdateLevelAndEmit(uuid.New(), 9999, 10)(); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected ErrMemberNotFound for a non-member, got %v", err)
+	}
+}
+
+func TestDeleteCharacter_DissolvesLinksAndRejectsLateCommands(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	seedMember(t, db, tenantId, 1000, nil, 2000)
+	seedMember(t, db, tenantId, 2000, ptr(1000), 3000, 4000)
+	seedMember(t, db, tenantId, 3000, ptr(2000))
+	seedMember(t, db, tenantId, 4000, ptr(2000))
+	seedMember(t, db, tenantId, 5000, nil)
+	p := newTestProcessor(t, db, tenantId)
+
+	updated, err := p.DeleteCharacterAndEmit(uuid.New(), 2000)()
+	if err != nil {
+		t.Fatalf("Failed to delete character: %v", err)
+	}
+	if len(updated) != 3 {
+		t.Errorf("Expected senior and both juniors to be affected, got %d", len(updated))
+	}
+
+	if _, err := p.GetByCharacterId(2000); !errors.Is(err, ErrCharacterDeleted) || !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected ErrCharacterDeleted for the deleted character, got %v", err)
+	}
+	if senior, _ := p.GetByCharacterId(1000); senior.HasJuniors() {
+		t.Errorf("Expected senior to lose the deleted junior, got %v", senior.JuniorIds())
+	}
+	for _, juniorId := range []uint32{3000, 4000} {
+		if junior, _ := p.GetByCharacterId(juniorId); junior.HasSenior() {
+			t.Errorf("Expected junior %d to lose the deleted senior", juniorId)
+		}
+	}
+
+	staged := stagedMessages(t, db, familymsg.EnvEventTopicStatus)
+	if len(staged) != 1 || !strings.Contains(string(staged[0].Value), familymsg.EventTypeTreeDissolved) {
+		t.Fatalf("Expected a single tree dissolved event, got %d events", len(staged))
+	}
+	var dissolved familymsg.Event[familymsg.TreeDissolvedEventBody]
+	if err := json.Unmarshal(staged[0].Value, &dissolved); err != nil {
+		t.Fatalf("Failed to decode tree dissolved event: %v", err)
+	}
+	if ids := dissolved.Body.AffectedIds; len(ids) != 4 || ids[0] != 2000 {
+		t.Errorf("Expected the deleted character and everyone linked to them to be affected, got %v", ids)
+	}
+
+	// Late commands for the deleted character are rejected instead of recreating it
+	if _, err := p.AddJunior(nil)(1, 2000, 50, 5000, 50)(); !errors.Is(err, ErrCharacterDeleted) {
+		t.Errorf("Expected ErrCharacterDeleted adding a junior to the deleted character, got %v", err)
+	}
+	if err := p.RejectDeletedAndEmit(1, 2000); !errors.Is(err, ErrCharacterDeleted) {
+		t.Errorf("Expected ErrCharacterDeleted rejecting the deleted character, got %v", err)
+	}
+	if staged := stagedMessages(t, db, familymsg.EnvEventTopicErrors); len(staged) != 1 || !strings.Contains(string(staged[0].Value), "CHARACTER_DELETED") {
+		t.Errorf("Expected a CHARACTER_DELETED error event, got %d events", len(staged))
+	}
+	if err := p.RejectDeletedAndEmit(1, 5000); err != nil {
+		t.Errorf("Expected a live character to be accepted, got %v", err)
+	}
+
+	// Deleting a character that never joined a family still tombstones them
+	if _, err := p.DeleteCharacterAndEmit(uuid.New(), 6000)(); err != nil {
+		t.Fatalf("Failed to delete non-member: %v", err)
+	}
+	if _, err := p.GetByCharacterId(6000); !errors.Is(err, ErrCharacterDeleted) {
+		t.Errorf("Expected ErrCharacterDeleted for the deleted non-member, got %v", err)
+	}
+}
+
+func TestAddJunior_RequiresSameWorldAndMap(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	seedMember(t, db, tenantId, 1000, nil)
+	seedMember(t, db, tenantId, 2000, nil)
+	p := newTestProcessor(t, db, tenantId).(*ProcessorImpl)
+	locations := location.NewMemoryProvider().Set(1000, location.NewModel(1, 100000000))
+	p.locations = locations
+
+	tests := []struct {
+		name   string
+		junior []location.Model
+		want   error
+		code   string
+	}{
+		{"Unknown location", nil, ErrLocationUnavailable, "LOCATION_UNAVAILABLE"},
+		{"Different world", []location.Model{location.NewModel(2, 100000000)}, ErrNotSameWorld, "NOT_SAME_WORLD"},
+		{"Different map", []location.Model{location.NewModel(1, 104000000)}, ErrNotOnSameMap, "NOT_ON_SAME_MAP"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, m := range tt.junior {
+				locations.Set(2000, m)
+			}
+			buf := message.NewBuffer()
+			if _, err := p.AddJunior(buf)(1, 1000, 50, 2000, 50)(); !errors.Is(err, tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, err)
+			}
+			ms := buf.GetAll()[familymsg.EnvEventTopicErrors]
+			if len(ms) != 1 || !strings.Contains(string(ms[0].Value), tt.code) {
+				t.Errorf("Expected a %s error event", tt.code)
+			}
+		})
+	}
+
+	locations.Set(2000, location.NewModel(1, 100000000))
+	if _, err := p.AddJunior(nil)(1, 1000, 50, 2000, 50)(); err != nil {
+		t.Errorf("Expected members on the same map to link, got %v", err)
+	}
+}
+
+func TestAddJunior_SuppliedLocation(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	seedMember(t, db, tenantId, 1000, nil)
+	seedMember(t, db, tenantId, 2000, nil)
+	p := newTestProcessor(t, db, tenantId).(*ProcessorImpl)
+	lookups := &countingProvider{next: location.NewMemoryProvider()}
+	p.locations = lookups
+
+	buf := message.NewBuffer()
+	if _, err := p.WithLocation(1000, location.NewModel(2, 100000000)).WithLocation(2000, location.NewModel(2, 100000000)).AddJunior(buf)(2, 1000, 50, 2000, 50)(); !errors.Is(err, ErrNotSameWorld) {
+		t.Fatalf("Expected members outside the requested world to be rejected, got %v", err)
+	}
+	if ms := buf.GetAll()[familymsg.EnvEventTopicErrors]; len(ms) != 1 || !strings.Contains(string(ms[0].Value), "NOT_SAME_WORLD") {
+		t.Error("Expected a NOT_SAME_WORLD error event")
+	}
+
+	if _, err := p.WithLocation(1000, location.NewModel(1, 100000000)).WithLocation(2000, location.NewModel(1, 104000000)).AddJunior(nil)(1, 1000, 50, 2000, 50)(); !errors.Is(err, ErrNotOnSameMap) {
+		t.Fatalf("Expected supplied maps to be compared, got %v", err)
+	}
+
+	if _, err := p.WithLocation(1000, location.NewModel(1, 100000000)).AddJunior(nil)(1, 1000, 50, 2000, 50)(); !errors.Is(err, ErrLocationUnavailable) {
+		t.Fatalf("Expected a missing location to fall back to the lookup, got %v", err)
+	}
+
+	if _, err := p.WithLocation(1000, location.NewModel(1, 100000000)).WithLocation(2000, location.NewModel(1, 100000000)).AddJunior(nil)(1, 1000, 50, 2000, 50)(); err != nil {
+		t.Errorf("Expected supplied locations on the same map to link, got %v", err)
+	}
+	if lookups.calls != 1 {
+		t.Errorf("Expected only the side without a supplied location to be looked up, got %d lookups", lookups.calls)
+	}
+}
+
+// countingProvider counts the lookups resolved through it
+type countingProvider struct {
+	next  location.LocationProvider
+	calls int
+}
+
+func (p *countingProvider) GetLocation(l logrus.FieldLogger, ctx context.Context, characterId uint32) (location.Model, error) {
+	p.calls++
+	return p.next.GetLocation(l, ctx, characterId)
+}
+
+func TestAddJunior_AutoEnroll(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	p := newTestProcessor(t, db, tenantId)
+
+	buf := message.NewBuffer()
+	if _, err := p.AddJunior(buf)(1, 1000, 50, 2000, 45)(); !errors.Is(err, ErrSeniorNotFound) {
+		t.Fatalf("Expected %v, got %v", ErrSeniorNotFound, err)
+	}
+	if _, err := p.GetByCharacterId(1000); !errors.Is(err, ErrMemberNotFound) {
+		t.Fatalf("Expected a rejected link not to enroll the senior, got %v", err)
+	}
+
+	buf = message.NewBuffer()
+	senior, err := p.WithAutoEnroll().AddJunior(buf)(1, 1000, 50, 2000, 45)()
+	if err != nil {
+		t.Fatalf("Failed to link with auto-enroll: %v", err)
+	}
+	if len(senior.JuniorIds()) != 1 || senior.JuniorIds()[0] != 2000 || senior.Level() != 50 {
+		t.Errorf("Expected enrolled senior to be linked to the junior, got %v at level %d", senior.JuniorIds(), senior.Level())
+	}
+	junior, err := p.GetByCharacterId(2000)
+	if err != nil || junior.SeniorId() == nil || *junior.SeniorId() != 1000 || junior.Level() != 45 {
+		t.Errorf("Expected enrolled junior to be linked to the senior, got %v", err)
+	}
+
+	created := 0
+	for _, m := range buf.GetAll()[familymsg.EnvEventTopicStatus] {
+		if strings.Contains(string(m.Value), familymsg.EventTypeMemberCreated) {
+			created++
+		}
+	}
+	if created != 2 {
+		t.Errorf("Expected a member created event per enrolled member, got %d", created)
+	}
+}
+
+func TestCreateMember(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	p := newTestProcessor(t, db, tenantId)
+
+	m, err := p.CreateMember(nil)(1, 1000, 30)()
+	if err != nil {
+		t.Fatalf("Failed to create member: %v", err)
+	}
+	if m.CharacterId() != 1000 || m.Level() != 30 || m.World() != 1 || m.HasSenior() {
+		t.Errorf("Unexpected member created")
+	}
+
+	tests := []struct {
+		name        string
+		characterId uint32
+		level       uint16
+		want        error
+		code        string
+	}{
+		{"Existing member", 1000, 30, ErrMemberAlreadyExists, "MEMBER_ALREADY_EXISTS"},
+		{"Invalid level", 2000, 0, ErrInvalidLevel, "INVALID_MEMBER"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := message.NewBuffer()
+			if _, err := p.CreateMember(buf)(1, tt.characterId, tt.level)(); !errors.Is(err, tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, err)
+			}
+			ms := buf.GetAll()[familymsg.EnvEventTopicErrors]
+			if len(ms) != 1 || !strings.Contains(string(ms[0].Value), tt.code) {
+				t.Errorf("Expected a %s error event", tt.code)
+			}
+		})
+	}
+}
+
+func TestLeaveSeniorAndExpelJunior(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+
+	previous := rules.GetRegistry()
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+	rules.SetRegistry(rules.NewRegistry(l, rules.NewMemorySource([]byte(`{"tenants": {"`+tenantId.String()+`": {"leaveRepCost": 100, "leaveCooldownSeconds": 3600}}}`))))
+	t.Cleanup(func() { rules.SetRegistry(previous) })
+
+	seedMember(t, db, tenantId, 1000, nil, 2000, 3000)
+	seedMember(t, db, tenantId, 2000, ptr(1000))
+	seedMember(t, db, tenantId, 3000, ptr(1000))
+	p := newTestProcessor(t, db, tenantId)
+
+	buf := message.NewBuffer()
+	if _, err := p.LeaveSenior(buf)(2000)(); !errors.Is(err, ErrInsufficientRep) {
+		t.Fatalf("Expected %v, got %v", ErrInsufficientRep, err)
+	}
+	if ms := buf.GetAll()[familymsg.EnvEventTopicErrors]; len(ms) != 1 || !strings.Contains(string(ms[0].Value), "INSUFFICIENT_REP") {
+		t.Errorf("Expected an INSUFFICIENT_REP error event")
+	}
+
+	if _, err := p.AwardRep(nil)(2000, 150, "QUEST")(); err != nil {
+		t.Fatalf("Failed to award rep: %v", err)
+	}
+	buf = message.NewBuffer()
+	if _, err := p.LeaveSenior(buf)(2000)(); err != nil {
+		t.Fatalf("Failed to leave senior: %v", err)
+	}
+	junior, _ := p.GetByCharacterId(2000)
+	senior, _ := p.GetByCharacterId(1000)
+	if junior.HasSenior() || junior.Rep() != 50 || senior.HasJunior(2000) || !senior.HasJunior(3000) {
+		t.Errorf("Expected only the leaving junior's link to be broken and the leave cost paid, junior rep %d", junior.Rep())
+	}
+	ms := buf.GetAll()[familymsg.EnvEventTopicStatus]
+	if len(ms) != 1 || !strings.Contains(string(ms[0].Value), string(ReasonLeftSenior)) || !strings.Contains(string(ms[0].Value), `"repCost":100`) || !strings.Contains(string(ms[0].Value), "cooldownUntil") {
+		t.Errorf("Expected a LINK_BROKEN event with reason %s, the leave cost and a cooldown", ReasonLeftSenior)
+	}
+	if len(buf.GetAll()[familymsg.EnvEventTopicRep]) != 0 {
+		t.Errorf("Expected the leave cost to be reported only on the LINK_BROKEN event")
+	}
+
+	if _, err := p.LeaveSenior(nil)(2000)(); !errors.Is(err, ErrNoSenior) {
+		t.Errorf("Expected %v, got %v", ErrNoSenior, err)
+	}
+	if _, err := p.ExpelJunior(nil)(1000, 2000)(); !errors.Is(err, ErrNotJunior) {
+		t.Errorf("Expected %v, got %v", ErrNotJunior, err)
+	}
+
+	buf = message.NewBuffer()
+	if _, err := p.ExpelJunior(buf)(1000, 3000)(); err != nil {
+		t.Fatalf("Failed to expel junior: %v", err)
+	}
+	junior, _ = p.GetByCharacterId(3000)
+	senior, _ = p.GetByCharacterId(1000)
+	if junior.HasSenior() || senior.HasJuniors() {
+		t.Errorf("Expected the expelled junior's link to be broken")
+	}
+	ms = buf.GetAll()[familymsg.EnvEventTopicStatus]
+	if len(ms) != 1 || !strings.Contains(string(ms[0].Value), string(ReasonExpelled)) {
+		t.Errorf("Expected a LINK_BROKEN event with reason %s", ReasonExpelled)
+	}
+}
+
+func TestRejoinCooldown(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+
+	seedMember(t, db, tenantId, 1000, nil, 2000)
+	seedMember(t, db, tenantId, 2000, ptr(1000))
+	seedMember(t, db, tenantId, 3000, nil)
+	p := newTestProcessor(t, db, tenantId)
+
+	if _, err := p.LeaveSenior(nil)(2000)(); err != nil {
+		t.Fatalf("Failed to leave senior: %v", err)
+	}
+	junior, _ := p.GetByCharacterId(2000)
+	if !junior.IsOnCooldown(time.Now()) {
+		t.Fatalf("Expected the leaving junior to be on cooldown")
+	}
+
+	buf := message.NewBuffer()
+	if _, err := p.AddJunior(buf)(1, 3000, 50, 2000, 50)(); !errors.Is(err, ErrCooldownActive) {
+		t.Fatalf("Expected %v, got %v", ErrCooldownActive, err)
+	}
+	if ms := buf.GetAll()[familymsg.EnvEventTopicErrors]; len(ms) != 1 || !strings.Contains(string(ms[0].Value), "COOLDOWN_ACTIVE") {
+		t.Errorf("Expected a COOLDOWN_ACTIVE error event")
+	}
+
+	buf = message.NewBuffer()
+	if _, err := p.ClearCooldown(buf)(2000)(); err != nil {
+		t.Fatalf("Failed to clear cooldown: %v", err)
+	}
+	if ms := buf.GetAll()[familymsg.EnvEventTopicStatus]; len(ms) != 1 || !strings.Contains(string(ms[0].Value), familymsg.EventTypeCooldownCleared) {
+		t.Errorf("Expected a COOLDOWN_CLEARED event")
+	}
+	if _, err := p.AddJunior(nil)(1, 3000, 50, 2000, 50)(); err != nil {
+		t.Fatalf("Expected link to succeed once the cooldown is cleared, got %v", err)
+	}
+}
+
+func TestDissolveFamily(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	for _, characterId := range []uint32{1000, 2000, 3000, 4000} {
+		seedMember(t, db, tenantId, characterId, nil)
+	}
+	p := newTestProcessor(t, db, tenantId)
+
+	for _, link := range [][2]uint32{{1000, 2000}, {1000, 3000}, {2000, 4000}} {
+		if _, err := p.AddJunior(nil)(1, link[0], 50, link[1], 50)(); err != nil {
+			t.Fatalf("Failed to add junior: %v", err)
+		}
+	}
+
+	buf := message.NewBuffer()
+	if _, err := p.DissolveFamily(buf)(2000)(); !errors.Is(err, ErrNotFamilyLeader) {
+		t.Fatalf("Expected %v, got %v", ErrNotFamilyLeader, err)
+	}
+	if ms := buf.GetAll()[familymsg.EnvEventTopicErrors]; len(ms) != 1 || !strings.Contains(string(ms[0].Value), "NOT_FAMILY_LEADER") {
+		t.Errorf("Expected a NOT_FAMILY_LEADER error event")
+	}
+
+	buf = message.NewBuffer()
+	updated, err := p.DissolveFamily(buf)(1000)()
+	if err != nil {
+		t.Fatalf("Failed to dissolve family: %v", err)
+	}
+	if len(updated) != 4 {
+		t.Errorf("Expected 4 updated members, got %d", len(updated))
+	}
+	for _, characterId := range []uint32{1000, 2000, 3000, 4000} {
+		m, _ := p.GetByCharacterId(characterId)
+		if m.HasSenior() || m.HasJuniors() || m.FamilyId() != nil {
+			t.Errorf("Expected member %d to be left without links or a family", characterId)
+		}
+	}
+	if _, err := GetFamilyByLeaderIdProvider(tenantId, 1000)(db)(); !errors.Is(err, ErrFamilyNotFound) {
+		t.Errorf("Expected family to be deleted, got %v", err)
+	}
+
+	ms := buf.GetAll()[familymsg.EnvEventTopicStatus]
+	if len(ms) != 4 || !strings.Contains(string(ms[0].Value), familymsg.EventTypeTreeDissolved) {
+		t.Fatalf("Expected one TREE_DISSOLVED and three LINK_BROKEN events, got %d events", len(ms))
+	}
+	for _, m := range ms[1:] {
+		if !strings.Contains(string(m.Value), familymsg.EventTypeLinkBroken) || !strings.Contains(string(m.Value), string(ReasonFamilyDissolved)) {
+			t.Errorf("Expected a LINK_BROKEN event with reason %s", ReasonFamilyDissolved)
+		}
+	}
+
+	if _, err := p.DissolveFamily(nil)(1000)(); !errors.Is(err, ErrFamilyNotFound) {
+		t.Errorf("Expected %v, got %v", ErrFamilyNotFound, err)
+	}
+}
+
+func TestPropagateRep_PenaltyComparesOriginatingJunior(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+
+	// 1000 -> 2000 -> 3000; the junior is below the grand-senior but the senior in between is above them
+	seedMember(t, db, tenantId, 1000, nil, 2000)
+	seedMember(t, db, tenantId, 2000, ptr(1000), 3000)
+	seedMember(t, db, tenantId, 3000, ptr(2000))
+	p := newTestProcessor(t, db, tenantId).(*ProcessorImpl)
+	p.repPropagationSplit = []uint32{100, 50}
+	for id, level := range map[uint32]uint16{1000: 50, 2000: 60, 3000: 40} {
+		if _, err := p.UpdateLevel(nil)(id, level)(); err != nil {
+			t.Fatalf("Failed to set level of %d: %v", id, err)
+		}
+	}
+
+	buf := message.NewBuffer()
+	if _, err := p.PropagateRep(buf)(3000, 100, "QUEST")(); err != nil {
+		t.Fatalf("Failed to propagate rep: %v", err)
+	}
+	senior, _ := p.GetByCharacterId(2000)
+	grandSenior, _ := p.GetByCharacterId(1000)
+	if senior.Rep() != 100 || grandSenior.Rep() != 50 {
+		t.Errorf("Expected unpenalised shares of 100 and 50, got %d and %d", senior.Rep(), grandSenior.Rep())
+	}
+	for _, m := range buf.GetAll()[familymsg.EnvEventTopicRep] {
+		if strings.Contains(string(m.Value), familymsg.EventTypeRepPenalized) {
+			t.Errorf("Expected no level penalty, got %s", m.Value)
+		}
+	}
+
+	// Once the junior outlevels the grand-senior only, only the grand-senior's share is halved
+	if _, err := p.UpdateLevel(nil)(3000, 55)(); err != nil {
+		t.Fatalf("Failed to set level: %v", err)
+	}
+	if _, err := p.PropagateRep(nil)(3000, 100, "QUEST")(); err != nil {
+		t.Fatalf("Failed to propagate rep: %v", err)
+	}
+	senior, _ = p.GetByCharacterId(2000)
+	grandSenior, _ = p.GetByCharacterId(1000)
+	if senior.Rep() != 200 || grandSenior.Rep() != 75 {
+		t.Errorf("Expected only the grand-senior's share to be halved, got %d and %d", senior.Rep(), grandSenior.Rep())
+	}
+}
+
+func TestAwardRep_DirectAwardIsNotPenalised(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	seedMember(t, db, tenantId, 1000, nil, 2000)
+	seedMember(t, db, tenantId, 2000, ptr(1000))
+	p := newTestProcessor(t, db, tenantId)
+	if _, err := p.UpdateLevel(nil)(2000, 70)(); err != nil {
+		t.Fatalf("Failed to set level: %v", err)
+	}
+
+	buf := message.NewBuffer()
+	junior, err := p.AwardRep(buf)(2000, 100, "QUEST")()
+	if err != nil {
+		t.Fatalf("Failed to award rep: %v", err)
+	}
+	if junior.Rep() != 100 {
+		t.Errorf("Expected a junior who outlevels their senior to receive the full award, got %d", junior.Rep())
+	}
+	for _, m := range buf.GetAll()[familymsg.EnvEventTopicRep] {
+		if strings.Contains(string(m.Value), familymsg.EventTypeRepPenalized) {
+			t.Errorf("Expected no level penalty on a direct award, got %s", m.Value)
+		}
+	}
+}
+
+func TestLeaveSenior_WithoutCooldown(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+
+	previous := rules.GetRegistry()
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+	rules.SetRegistry(rules.NewRegistry(l, rules.NewMemorySource([]byte(`{"tenants": {"`+tenantId.String()+`": {"leaveCooldownSeconds": 0}}}`))))
+	t.Cleanup(func() { rules.SetRegistry(previous) })
+
+	seedMember(t, db, tenantId, 1000, nil, 2000)
+	seedMember(t, db, tenantId, 2000, ptr(1000))
+	p := newTestProcessor(t, db, tenantId)
+
+	buf := message.NewBuffer()
+	if _, err := p.LeaveSenior(buf)(2000)(); err != nil {
+		t.Fatalf("Failed to leave senior: %v", err)
+	}
+	junior, _ := p.GetByCharacterId(2000)
+	if junior.CooldownUntil() != nil {
+		t.Errorf("Expected no cooldown to be recorded, got %v", junior.CooldownUntil())
+	}
+	ms := buf.GetAll()[familymsg.EnvEventTopicStatus]
+	if len(ms) != 1 || strings.Contains(string(ms[0].Value), "cooldownUntil") {
+		t.Errorf("Expected a LINK_BROKEN event without a cooldown")
+	}
+	if _, err := p.AddJunior(nil)(1, 1000, 50, 2000, 50)(); err != nil {
+		t.Errorf("Expected the junior to rejoin immediately, got %v", err)
+	}
+}
+
+func TestBreakLink_StartsRejoinCooldown(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+
+	seedMember(t, db, tenantId, 1000, nil, 2000)
+	seedMember(t, db, tenantId, 2000, ptr(1000), 3000)
+	seedMember(t, db, tenantId, 3000, ptr(2000))
+	seedMember(t, db, tenantId, 4000, nil)
+	p := newTestProcessor(t, db, tenantId)
+
+	buf := message.NewBuffer()
+	if _, err := p.BreakLink(buf)(2000)(); err != nil {
+		t.Fatalf("Failed to break link: %v", err)
+	}
+	ms := buf.GetAll()[familymsg.EnvEventTopicStatus]
+	if len(ms) != 2 {
+		t.Fatalf("Expected a LINK_BROKEN event per link, got %d", len(ms))
+	}
+	for _, m := range ms {
+		if !strings.Contains(string(m.Value), string(ReasonBreakLink)) {
+			t.Errorf("Expected a LINK_BROKEN event with reason %s, got %s", ReasonBreakLink, m.Value)
+		}
+	}
+	for _, juniorId := range []uint32{2000, 3000} {
+		if _, err := p.AddJunior(nil)(1, 4000, 50, juniorId, 50)(); !errors.Is(err, ErrCooldownActive) {
+			t.Errorf("Expected %v relinking %d, got %v", ErrCooldownActive, juniorId, err)
+		}
+	}
+	senior, _ := p.GetByCharacterId(1000)
+	if senior.IsOnCooldown(time.Now()) {
+		t.Errorf("Expected the former senior not to be on cooldown")
+	}
+}
+
+func TestMaxJuniors_LoweredLimitKeepsExistingMembers(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+
+	previous := rules.GetRegistry()
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+	rules.SetRegistry(rules.NewRegistry(l, rules.NewMemorySource([]byte(`{"tenants": {"`+tenantId.String()+`": {"maxJuniors": 1}}}`))))
+	t.Cleanup(func() { rules.SetRegistry(previous) })
+
+	// Linked under the previous limit of two juniors
+	seedMember(t, db, tenantId, 1000, nil, 2000, 3000)
+	seedMember(t, db, tenantId, 2000, ptr(1000))
+	seedMember(t, db, tenantId, 3000, ptr(1000))
+	seedMember(t, db, tenantId, 4000, nil)
+	p := newTestProcessor(t, db, tenantId)
+
+	if _, err := p.GetByCharacterId(1000); err != nil {
+		t.Fatalf("Expected senior above the lowered limit to load, got %v", err)
+	}
+	if _, err := p.AwardRep(nil)(1000, 10, "QUEST")(); err != nil {
+		t.Errorf("Expected senior above the lowered limit to be updated, got %v", err)
+	}
+	if _, err := p.AddJunior(nil)(1, 1000, 50, 4000, 50)(); !errors.Is(err, ErrSeniorHasTooManyJuniors) {
+		t.Errorf("Expected %v, got %v", ErrSeniorHasTooManyJuniors, err)
+	}
+	if _, err := p.ExpelJunior(nil)(1000, 3000)(); err != nil {
+		t.Errorf("Expected senior above the lowered limit to expel a junior, got %v", err)
+	}
+}
+
+func TestSyncFamilies_BeyondPedigreeDepth(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	chain := seedChain(t, db, tenantId, 1000, MaxPedigreeDepth+10)
+	seedMember(t, db, tenantId, 9999, nil)
+	p := newTestProcessor(t, db, tenantId)
+
+	if _, err := p.AddJunior(nil)(1, chain[len(chain)-1], 50, 9999, 50)(); err != nil {
+		t.Fatalf("Failed to add junior: %v", err)
+	}
+	f, err := p.GetFamilyByMemberId(9999)
+	if err != nil {
+		t.Fatalf("Failed to load family: %v", err)
+	}
+	if f.LeaderId() != chain[0] || f.MemberCount() != uint32(len(chain)+1) {
+		t.Errorf("Expected family led by %d with %d members, got leader %d with %d members", chain[0], len(chain)+1, f.LeaderId(), f.MemberCount())
+	}
+}
+
+func TestDissolveFamily_BeyondPedigreeDepth(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	chain := seedChain(t, db, tenantId, 1000, MaxPedigreeDepth+10)
+	p := newTestProcessor(t, db, tenantId)
+
+	updated, err := p.DissolveFamily(nil)(chain[0])()
+	if err != nil {
+		t.Fatalf("Failed to dissolve family: %v", err)
+	}
+	if len(updated) != len(chain) {
+		t.Errorf("Expected %d updated members, got %d", len(chain), len(updated))
+	}
+	for _, characterId := range chain {
+		m, err := p.GetByCharacterId(characterId)
+		if err != nil {
+			t.Fatalf("Failed to load member %d: %v", characterId, err)
+		}
+		if m.HasSenior() || m.HasJuniors() {
+			t.Errorf("Expected member %d to be left without links", characterId)
+		}
+	}
+}
@@ -19,16 +19,24 @@ func LinkCreatedEventProvider(worldId byte, characterId uint32, seniorId uint32,
 }
 
 // LinkBrokenEventProvider creates a Kafka message provider for link broken events
-func LinkBrokenEventProvider(worldId byte, characterId uint32, seniorId uint32, juniorId uint32, reason string) model.Provider[[]kafka.Message] {
+func LinkBrokenEventProvider(worldId byte, characterId uint32, seniorId uint32, juniorId uint32, reason LinkBrokenReason) model.Provider[[]kafka.Message] {
 	key := producer.CreateKey(int(characterId))
-	value := family.NewLinkBrokenEvent(worldId, characterId, seniorId, juniorId, reason)
+	value := family.NewLinkBrokenEvent(worldId, characterId, seniorId, juniorId, string(reason))
+	return producer.SingleMessageProvider(key, value)
+}
+
+// LinkSeveredEventProvider creates a Kafka message provider for link broken events of a junior leaving or being
+// expelled
+func LinkSeveredEventProvider(worldId byte, characterId uint32, seniorId uint32, juniorId uint32, reason LinkBrokenReason, repCost uint32, cooldownUntil *time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := family.NewLinkSeveredEvent(worldId, characterId, seniorId, juniorId, string(reason), repCost, cooldownUntil)
 	return producer.SingleMessageProvider(key, value)
 }
 
 // RepGainedEventProvider creates a Kafka message provider for reputation gained events
-func RepGainedEventProvider(worldId byte, characterId uint32, repGained uint32, dailyRep uint32, source string) model.Provider[[]kafka.Message] {
+func RepGainedEventProvider(worldId byte, characterId uint32, repGained uint32, dailyRep uint32, source string, sourceCharacterId uint32) model.Provider[[]kafka.Message] {
 	key := producer.CreateKey(int(characterId))
-	value := family.NewRepGainedEvent(worldId, characterId, repGained, dailyRep, source)
+	value := family.NewRepGainedEvent(worldId, characterId, repGained, dailyRep, source, sourceCharacterId)
 	return producer.SingleMessageProvider(key, value)
 }
 
@@ -53,8 +61,15 @@ func LinkErrorEventProvider(worldId byte, characterId uint32, seniorId uint32, j
 	return producer.SingleMessageProvider(key, value)
 }
 
+// FamilyErrorEventProvider creates a Kafka message provider for family error events
+func FamilyErrorEventProvider(worldId byte, characterId uint32, familyId uint32, errorCode string, errorMessage string) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := family.NewFamilyErrorEvent(worldId, characterId, familyId, errorCode, errorMessage)
+	return producer.SingleMessageProvider(key, value)
+}
+
 // TreeDissolvedEventProvider creates a Kafka message provider for tree dissolved events
-func TreeDissolvedEventProvider(worldId byte, characterId uint32, seniorId uint32, affectedIds []uint32, reason string) model.Provider[[]kafka.Message] {
+func TreeDissolvedEventProvider(worldId byte, characterId uint32, seniorId uint32, affectedIds []uint32, reason LinkBrokenReason) model.Provider[[]kafka.Message] {
 	key := producer.CreateKey(int(characterId))
 	value := &family.Event[family.TreeDissolvedEventBody]{
 		WorldId:     worldId,
@@ -63,7 +78,7 @@ func TreeDissolvedEventProvider(worldId byte, characterId uint32, seniorId uint3
 		Body: family.TreeDissolvedEventBody{
 			SeniorId:    seniorId,
 			AffectedIds: affectedIds,
-			Reason:      reason,
+			Reason:      string(reason),
 			Timestamp:   time.Now(),
 		},
 	}
@@ -118,8 +133,50 @@ func RepPenalizedEventProvider(worldId byte, characterId uint32, repLost uint32,
 	return producer.SingleMessageProvider(key, value)
 }
 
+// FamilyRenamedEventProvider creates a Kafka message provider for family renamed events
+func FamilyRenamedEventProvider(worldId byte, characterId uint32, familyId uint32, name string, previousName string) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(familyId))
+	value := family.NewFamilyRenamedEvent(worldId, characterId, familyId, name, previousName)
+	return producer.SingleMessageProvider(key, value)
+}
+
+// FamilyTextUpdatedEventProvider creates a Kafka message provider for precept and notice updated events
+func FamilyTextUpdatedEventProvider(eventType string, worldId byte, characterId uint32, familyId uint32, text string) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(familyId))
+	value := family.NewFamilyTextUpdatedEvent(eventType, worldId, characterId, familyId, text)
+	return producer.SingleMessageProvider(key, value)
+}
+
 // Command Providers
 
+// MemberCreatedEventProvider creates a Kafka message provider for member created events
+func MemberCreatedEventProvider(worldId byte, characterId uint32, level uint16) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := family.NewMemberCreatedEvent(worldId, characterId, level)
+	return producer.SingleMessageProvider(key, value)
+}
+
+// CooldownClearedEventProvider creates a Kafka message provider for cooldown cleared events
+func CooldownClearedEventProvider(worldId byte, characterId uint32, previousCooldownUntil time.Time) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := family.NewCooldownClearedEvent(worldId, characterId, previousCooldownUntil)
+	return producer.SingleMessageProvider(key, value)
+}
+
+// MemberUpdatedEventProvider creates a Kafka message provider for member updated events
+func MemberUpdatedEventProvider(worldId byte, characterId uint32, level uint16, previousLevel uint16, previousWorld byte) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := family.NewMemberUpdatedEvent(worldId, characterId, level, previousLevel, previousWorld)
+	return producer.SingleMessageProvider(key, value)
+}
+
+// CreateMemberCommandProvider creates a Kafka message provider for create member commands
+func CreateMemberCommandProvider(transactionId uuid.UUID, worldId byte, characterId uint32, level uint16) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := family.NewCreateMemberCommand(transactionId, worldId, characterId, level)
+	return producer.SingleMessageProvider(key, value)
+}
+
 // AddJuniorCommandProvider creates a Kafka message provider for add junior commands
 func AddJuniorCommandProvider(transactionId uuid.UUID, worldId byte, characterId uint32, juniorId uint32, seniorLevel uint16, juniorLevel uint16) model.Provider[[]kafka.Message] {
 	key := producer.CreateKey(int(characterId))
@@ -135,9 +192,37 @@ func RemoveMemberCommandProvider(transactionId uuid.UUID, worldId byte, characte
 }
 
 // BreakLinkCommandProvider creates a Kafka message provider for break link commands
-func BreakLinkCommandProvider(transactionId uuid.UUID, worldId byte, characterId uint32, reason string) model.Provider[[]kafka.Message] {
+func BreakLinkCommandProvider(transactionId uuid.UUID, worldId byte, characterId uint32) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := family.NewBreakLinkCommand(transactionId, worldId, characterId)
+	return producer.SingleMessageProvider(key, value)
+}
+
+// LeaveSeniorCommandProvider creates a Kafka message provider for leave senior commands
+func LeaveSeniorCommandProvider(transactionId uuid.UUID, worldId byte, characterId uint32) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := family.NewLeaveSeniorCommand(transactionId, worldId, characterId)
+	return producer.SingleMessageProvider(key, value)
+}
+
+// ExpelJuniorCommandProvider creates a Kafka message provider for expel junior commands
+func ExpelJuniorCommandProvider(transactionId uuid.UUID, worldId byte, characterId uint32, juniorId uint32) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := family.NewExpelJuniorCommand(transactionId, worldId, characterId, juniorId)
+	return producer.SingleMessageProvider(key, value)
+}
+
+// ClearCooldownCommandProvider creates a Kafka message provider for clear cooldown commands
+func ClearCooldownCommandProvider(transactionId uuid.UUID, worldId byte, characterId uint32) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := family.NewClearCooldownCommand(transactionId, worldId, characterId)
+	return producer.SingleMessageProvider(key, value)
+}
+
+// DissolveFamilyCommandProvider creates a Kafka message provider for dissolve family commands
+func DissolveFamilyCommandProvider(transactionId uuid.UUID, worldId byte, characterId uint32) model.Provider[[]kafka.Message] {
 	key := producer.CreateKey(int(characterId))
-	value := family.NewBreakLinkCommand(transactionId, worldId, characterId, reason)
+	value := family.NewDissolveFamilyCommand(transactionId, worldId, characterId)
 	return producer.SingleMessageProvider(key, value)
 }
 
@@ -5,14 +5,15 @@ import (
 	"errors"
 
 	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 // GetByCharacterIdProvider returns a provider for finding a family member by character ID
-func GetByCharacterIdProvider(characterId uint32) database.EntityProvider[Entity] {
+func GetByCharacterIdProvider(tenantId uuid.UUID, characterId uint32) database.EntityProvider[Entity] {
 	return func(db *gorm.DB) model.Provider[Entity] {
 		var entity Entity
-		if err := db.Where("character_id = ?", characterId).First(&entity).Error; err != nil {
+		if err := db.Where("tenant_id = ? AND character_id = ?", tenantId, characterId).First(&entity).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return model.ErrorProvider[Entity](ErrMemberNotFound)
 			}
@@ -23,10 +24,10 @@ func GetByCharacterIdProvider(characterId uint32) database.EntityProvider[Entity
 }
 
 // GetByIdProvider returns a provider for finding a family member by ID
-func GetByIdProvider(id uint32) database.EntityProvider[Entity] {
+func GetByIdProvider(tenantId uuid.UUID, id uint32) database.EntityProvider[Entity] {
 	return func(db *gorm.DB) model.Provider[Entity] {
 		var entity Entity
-		if err := db.Where("id = ?", id).First(&entity).Error; err != nil {
+		if err := db.Where("tenant_id = ? AND id = ?", tenantId, id).First(&entity).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return model.ErrorProvider[Entity](ErrMemberNotFound)
 			}
@@ -37,10 +38,10 @@ func GetByIdProvider(id uint32) database.EntityProvider[Entity] {
 }
 
 // GetBySeniorIdProvider returns a provider for finding all juniors of a senior
-func GetBySeniorIdProvider(seniorId uint32) database.EntityProvider[[]Entity] {
+func GetBySeniorIdProvider(tenantId uuid.UUID, seniorId uint32) database.EntityProvider[[]Entity] {
 	return func(db *gorm.DB) model.Provider[[]Entity] {
 		var entities []Entity
-		if err := db.Where("senior_id = ?", seniorId).Find(&entities).Error; err != nil {
+		if err := db.Where("tenant_id = ? AND senior_id = ?", tenantId, seniorId).Find(&entities).Error; err != nil {
 			return model.ErrorProvider[[]Entity](err)
 		}
 		return model.FixedProvider(entities)
@@ -48,10 +49,10 @@ func GetBySeniorIdProvider(seniorId uint32) database.EntityProvider[[]Entity] {
 }
 
 // GetFamilyTreeProvider returns a provider for getting a complete family tree starting from a character
-func GetFamilyTreeProvider(characterId uint32) database.EntityProvider[[]Entity] {
+func GetFamilyTreeProvider(tenantId uuid.UUID, characterId uint32) database.EntityProvider[[]Entity] {
 	return func(db *gorm.DB) model.Provider[[]Entity] {
 		// Get the starting member
-		member, err := GetByCharacterIdProvider(characterId)(db)()
+		member, err := GetByCharacterIdProvider(tenantId, characterId)(db)()
 		if err != nil {
 			return model.ErrorProvider[[]Entity](err)
 		}
@@ -68,7 +69,7 @@ func GetFamilyTreeProvider(characterId uint32) database.EntityProvider[[]Entity]
 		if member.SeniorId != nil {
 			seniorId := *member.SeniorId
 			if !relatedIds[seniorId] {
-				senior, err := GetByCharacterIdProvider(seniorId)(db)()
+				senior, err := GetByCharacterIdProvider(tenantId, seniorId)(db)()
 				if err == nil {
 					familyMembers = append(familyMembers, senior)
 					relatedIds[seniorId] = true
@@ -78,7 +79,7 @@ func GetFamilyTreeProvider(characterId uint32) database.EntityProvider[[]Entity]
 
 		// Add juniors if exist
 		if len(member.JuniorIds) > 0 {
-			juniors, err := GetBySeniorIdProvider(characterId)(db)()
+			juniors, err := GetBySeniorIdProvider(tenantId, characterId)(db)()
 			if err == nil {
 				for _, junior := range juniors {
 					if !relatedIds[junior.CharacterId] {
@@ -91,7 +92,7 @@ func GetFamilyTreeProvider(characterId uint32) database.EntityProvider[[]Entity]
 
 		// Add siblings (other juniors of the same senior)
 		if member.SeniorId != nil {
-			siblings, err := GetBySeniorIdProvider(*member.SeniorId)(db)()
+			siblings, err := GetBySeniorIdProvider(tenantId, *member.SeniorId)(db)()
 			if err == nil {
 				for _, sibling := range siblings {
 					if !relatedIds[sibling.CharacterId] {
@@ -105,15 +106,216 @@ func GetFamilyTreeProvider(characterId uint32) database.EntityProvider[[]Entity]
 	}
 }
 
+// GetRootAncestorProvider returns a provider for the topmost senior reachable from a character
+func GetRootAncestorProvider(tenantId uuid.UUID, characterId uint32) database.EntityProvider[Entity] {
+	return func(db *gorm.DB) model.Provider[Entity] {
+		if db.Dialector.Name() == "postgres" {
+			var entities []PedigreeEntity
+			err := db.Raw(`
+				WITH RECURSIVE ancestors AS (
+					SELECT fm.*, 0 AS depth FROM family_members fm WHERE fm.tenant_id = ? AND fm.character_id = ?
+					UNION ALL
+					SELECT fm.*, a.depth + 1 FROM family_members fm
+					JOIN ancestors a ON fm.tenant_id = a.tenant_id AND fm.character_id = a.senior_id
+					WHERE a.depth < ?
+				)
+				SELECT * FROM ancestors ORDER BY depth DESC LIMIT 1
+			`, tenantId, characterId, MaxPedigreeDepth).Scan(&entities).Error
+			if err != nil {
+				return model.ErrorProvider[Entity](err)
+			}
+			if len(entities) == 0 {
+				return model.ErrorProvider[Entity](ErrMemberNotFound)
+			}
+			return model.FixedProvider(entities[0].Entity)
+		}
+
+		// Iterative fallback, one query per generation
+		current, err := GetByCharacterIdProvider(tenantId, characterId)(db)()
+		if err != nil {
+			return model.ErrorProvider[Entity](err)
+		}
+		for depth := 0; depth < MaxPedigreeDepth && current.SeniorId != nil; depth++ {
+			senior, err := GetByCharacterIdProvider(tenantId, *current.SeniorId)(db)()
+			if err != nil {
+				if errors.Is(err, ErrMemberNotFound) {
+					break
+				}
+				return model.ErrorProvider[Entity](err)
+			}
+			current = senior
+		}
+		return model.FixedProvider(current)
+	}
+}
+
+// GetAncestryProvider returns a provider for the character IDs of every senior above a character, nearest first. The
+// walk is not bounded by MaxPedigreeDepth, since cycle detection must see every ancestor up to the root.
+func GetAncestryProvider(tenantId uuid.UUID, characterId uint32) database.EntityProvider[[]uint32] {
+	return func(db *gorm.DB) model.Provider[[]uint32] {
+		return func() ([]uint32, error) {
+			ancestry := make([]uint32, 0)
+			visited := map[uint32]bool{characterId: true}
+			current := characterId
+			for {
+				var entities []Entity
+				if err := db.Select("senior_id").Where("tenant_id = ? AND character_id = ?", tenantId, current).Limit(1).Find(&entities).Error; err != nil {
+					return nil, err
+				}
+				if len(entities) == 0 || entities[0].SeniorId == nil {
+					break
+				}
+
+				seniorId := *entities[0].SeniorId
+				ancestry = append(ancestry, seniorId)
+				// Stop on a pre-existing loop rather than walking it forever
+				if visited[seniorId] {
+					break
+				}
+				visited[seniorId] = true
+				current = seniorId
+			}
+			return ancestry, nil
+		}
+	}
+}
+
+// GetDescendantsProvider returns a provider for a root and its descendants up to maxDepth generations below it
+func GetDescendantsProvider(tenantId uuid.UUID, rootId uint32, maxDepth uint32) database.EntityProvider[[]PedigreeEntity] {
+	return func(db *gorm.DB) model.Provider[[]PedigreeEntity] {
+		if maxDepth == 0 || maxDepth > MaxPedigreeDepth {
+			maxDepth = MaxPedigreeDepth
+		}
+
+		if db.Dialector.Name() == "postgres" {
+			var entities []PedigreeEntity
+			err := db.Raw(`
+				WITH RECURSIVE pedigree AS (
+					SELECT fm.*, 0 AS depth FROM family_members fm WHERE fm.tenant_id = ? AND fm.character_id = ?
+					UNION ALL
+					SELECT fm.*, p.depth + 1 FROM family_members fm
+					JOIN pedigree p ON fm.tenant_id = p.tenant_id AND fm.senior_id = p.character_id
+					WHERE p.depth < ?
+				)
+				SELECT * FROM pedigree ORDER BY depth, character_id
+			`, tenantId, rootId, maxDepth).Scan(&entities).Error
+			if err != nil {
+				return model.ErrorProvider[[]PedigreeEntity](err)
+			}
+			if len(entities) == 0 {
+				return model.ErrorProvider[[]PedigreeEntity](ErrMemberNotFound)
+			}
+			return model.FixedProvider(entities)
+		}
+
+		// Iterative fallback, one query per generation
+		root, err := GetByCharacterIdProvider(tenantId, rootId)(db)()
+		if err != nil {
+			return model.ErrorProvider[[]PedigreeEntity](err)
+		}
+		entities := []PedigreeEntity{{Entity: root}}
+		visited := map[uint32]bool{rootId: true}
+		frontier := []uint32{rootId}
+		for depth := uint32(1); depth <= maxDepth && len(frontier) > 0; depth++ {
+			var generation []Entity
+			if err := db.Where("tenant_id = ? AND senior_id IN ?", tenantId, frontier).Order("character_id").Find(&generation).Error; err != nil {
+				return model.ErrorProvider[[]PedigreeEntity](err)
+			}
+
+			frontier = frontier[:0]
+			for _, e := range generation {
+				if visited[e.CharacterId] {
+					continue
+				}
+				visited[e.CharacterId] = true
+				entities = append(entities, PedigreeEntity{Entity: e, Depth: depth})
+				frontier = append(frontier, e.CharacterId)
+			}
+		}
+		return model.FixedProvider(entities)
+	}
+}
+
+// GetSubtreeProvider returns a provider for a root and every descendant below it, however deep. Unlike
+// GetDescendantsProvider it is not bounded by MaxPedigreeDepth, so it can be used to change a whole tree.
+func GetSubtreeProvider(tenantId uuid.UUID, rootId uint32) database.EntityProvider[[]Entity] {
+	return func(db *gorm.DB) model.Provider[[]Entity] {
+		root, err := GetByCharacterIdProvider(tenantId, rootId)(db)()
+		if err != nil {
+			return model.ErrorProvider[[]Entity](err)
+		}
+		entities := []Entity{root}
+		visited := map[uint32]bool{rootId: true}
+		frontier := []uint32{rootId}
+		for len(frontier) > 0 {
+			var generation []Entity
+			if err := db.Where("tenant_id = ? AND senior_id IN ?", tenantId, frontier).Order("character_id").Find(&generation).Error; err != nil {
+				return model.ErrorProvider[[]Entity](err)
+			}
+
+			frontier = frontier[:0]
+			for _, e := range generation {
+				if visited[e.CharacterId] {
+					continue
+				}
+				visited[e.CharacterId] = true
+				entities = append(entities, e)
+				frontier = append(frontier, e.CharacterId)
+			}
+		}
+		return model.FixedProvider(entities)
+	}
+}
+
 // ExistsProvider returns a provider for checking if a family member exists by character ID
-func ExistsProvider(characterId uint32) database.EntityProvider[bool] {
+func ExistsProvider(tenantId uuid.UUID, characterId uint32) database.EntityProvider[bool] {
 	return func(db *gorm.DB) model.Provider[bool] {
 		return func() (bool, error) {
 			var count int64
-			if err := db.Model(&Entity{}).Where("character_id = ?", characterId).Count(&count).Error; err != nil {
+			if err := db.Model(&Entity{}).Where("tenant_id = ? AND character_id = ?", tenantId, characterId).Count(&count).Error; err != nil {
 				return false, err
 			}
 			return count > 0, nil
 		}
 	}
 }
+
+// GetFamilyByIdProvider returns a provider for finding a family aggregate by ID
+func GetFamilyByIdProvider(tenantId uuid.UUID, familyId uint32) database.EntityProvider[FamilyEntity] {
+	return func(db *gorm.DB) model.Provider[FamilyEntity] {
+		var entity FamilyEntity
+		if err := db.Where("tenant_id = ? AND id = ?", tenantId, familyId).First(&entity).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrorProvider[FamilyEntity](ErrFamilyNotFound)
+			}
+			return model.ErrorProvider[FamilyEntity](err)
+		}
+		return model.FixedProvider(entity)
+	}
+}
+
+// GetFamilyByLeaderIdProvider returns a provider for finding the family aggregate led by a character
+func GetFamilyByLeaderIdProvider(tenantId uuid.UUID, leaderId uint32) database.EntityProvider[FamilyEntity] {
+	return func(db *gorm.DB) model.Provider[FamilyEntity] {
+		var entity FamilyEntity
+		if err := db.Where("tenant_id = ? AND leader_id = ?", tenantId, leaderId).First(&entity).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrorProvider[FamilyEntity](ErrFamilyNotFound)
+			}
+			return model.ErrorProvider[FamilyEntity](err)
+		}
+		return model.FixedProvider(entity)
+	}
+}
+
+// GetTenantIdsProvider returns a provider for the IDs of every tenant with at least one family member. It is the only
+// provider which spans tenants, for maintenance jobs which then work through each tenant in turn.
+func GetTenantIdsProvider() database.EntityProvider[[]uuid.UUID] {
+	return func(db *gorm.DB) model.Provider[[]uuid.UUID] {
+		var tenantIds []uuid.UUID
+		if err := db.Model(&Entity{}).Distinct("tenant_id").Order("tenant_id").Pluck("tenant_id", &tenantIds).Error; err != nil {
+			return model.ErrorProvider[[]uuid.UUID](err)
+		}
+		return model.FixedProvider(tenantIds)
+	}
+}
@@ -0,0 +1,162 @@
+package family
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"atlas-family/idempotency"
+	"atlas-family/ledger"
+	"atlas-family/outbox"
+	"atlas-family/tombstone"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDatabase opens an isolated in-memory SQLite database with the family schemas applied
+func newTestDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := Migration(db); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := FamilyMigration(db); err != nil {
+		t.Fatalf("Failed to migrate families: %v", err)
+	}
+	if err := ledger.Migration(db); err != nil {
+		t.Fatalf("Failed to migrate reputation ledger: %v", err)
+	}
+	if err := idempotency.Migration(db); err != nil {
+		t.Fatalf("Failed to migrate processed commands: %v", err)
+	}
+	if err := outbox.Migration(db); err != nil {
+		t.Fatalf("Failed to migrate outbox: %v", err)
+	}
+	if err := tombstone.Migration(db); err != nil {
+		t.Fatalf("Failed to migrate tombstones: %v", err)
+	}
+	return db
+}
+
+// seedMember inserts a family member linked to the given senior and juniors
+func seedMember(t *testing.T, db *gorm.DB, tenantId uuid.UUID, characterId uint32, seniorId *uint32, juniorIds ...uint32) {
+	t.Helper()
+	entity := Entity{
+		CharacterId: characterId,
+		TenantId:    tenantId,
+		SeniorId:    seniorId,
+		JuniorIds:   juniorIds,
+		Level:       50,
+		World:       1,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if err := db.Create(&entity).Error; err != nil {
+		t.Fatalf("Failed to seed member %d: %v", characterId, err)
+	}
+}
+
+func ptr(v uint32) *uint32 {
+	return &v
+}
+
+func TestGetByCharacterId_UsesCharacterId(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+
+	// The first member's primary key matches the second member's character ID
+	seedMember(t, db, tenantId, 2000, nil)
+	seedMember(t, db, tenantId, 1, nil)
+
+	p := newTestProcessor(t, db, tenantId)
+	for _, characterId := range []uint32{1, 2000} {
+		m, err := p.GetByCharacterId(characterId)
+		if err != nil {
+			t.Fatalf("Failed to get member %d: %v", characterId, err)
+		}
+		if m.CharacterId() != characterId {
+			t.Errorf("Expected member %d, got %d", characterId, m.CharacterId())
+		}
+	}
+}
+
+func TestGetPedigree_SQLite(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+
+	// 1000 -> (2000 -> 4000 -> 5000), 3000
+	seedMember(t, db, tenantId, 1000, nil, 2000, 3000)
+	seedMember(t, db, tenantId, 2000, ptr(1000), 4000)
+	seedMember(t, db, tenantId, 3000, ptr(1000))
+	seedMember(t, db, tenantId, 4000, ptr(2000), 5000)
+	seedMember(t, db, tenantId, 5000, ptr(4000))
+
+	root, err := GetRootAncestorProvider(tenantId, 5000)(db)()
+	if err != nil {
+		t.Fatalf("Failed to resolve root ancestor: %v", err)
+	}
+	if root.CharacterId != 1000 {
+		t.Errorf("Expected root 1000, got %d", root.CharacterId)
+	}
+
+	entities, err := GetDescendantsProvider(tenantId, root.CharacterId, 0)(db)()
+	if err != nil {
+		t.Fatalf("Failed to get descendants: %v", err)
+	}
+	pedigree, err := MakePedigree(root.CharacterId, entities)
+	if err != nil {
+		t.Fatalf("Failed to make pedigree: %v", err)
+	}
+
+	expected := map[uint32]struct {
+		depth    uint32
+		parentId uint32
+	}{
+		1000: {0, 0},
+		2000: {1, 1000},
+		3000: {1, 1000},
+		4000: {2, 2000},
+		5000: {3, 4000},
+	}
+	if len(pedigree.Nodes()) != len(expected) {
+		t.Fatalf("Expected %d nodes, got %d", len(expected), len(pedigree.Nodes()))
+	}
+	for _, n := range pedigree.Nodes() {
+		e := expected[n.Member().CharacterId()]
+		if n.Depth() != e.depth {
+			t.Errorf("Expected depth %d for %d, got %d", e.depth, n.Member().CharacterId(), n.Depth())
+		}
+		parentId := uint32(0)
+		if n.ParentId() != nil {
+			parentId = *n.ParentId()
+		}
+		if parentId != e.parentId {
+			t.Errorf("Expected parent %d for %d, got %d", e.parentId, n.Member().CharacterId(), parentId)
+		}
+	}
+	if pedigree.Depth() != 3 {
+		t.Errorf("Expected pedigree depth 3, got %d", pedigree.Depth())
+	}
+
+	limited, err := GetDescendantsProvider(tenantId, root.CharacterId, 1)(db)()
+	if err != nil {
+		t.Fatalf("Failed to get limited descendants: %v", err)
+	}
+	if len(limited) != 3 {
+		t.Errorf("Expected 3 members within one generation, got %d", len(limited))
+	}
+}
+
+func TestGetPedigree_NotFound(t *testing.T) {
+	db := newTestDatabase(t)
+
+	if _, err := GetRootAncestorProvider(uuid.New(), 9999)(db)(); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected ErrMemberNotFound, got %v", err)
+	}
+}
@@ -1,10 +1,13 @@
 package family
 
 import (
+	"atlas-family/database"
+	"atlas-family/location"
 	"atlas-family/rest"
-	"encoding/json"
+	"atlas-family/textfilter"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/Chronicle20/atlas-model/model"
 	"github.com/Chronicle20/atlas-rest/server"
@@ -21,9 +24,28 @@ func InitResource(si jsonapi.ServerInformation) func(db *gorm.DB) server.RouteIn
 		return func(router *mux.Router, l logrus.FieldLogger) {
 
 			// Family management endpoints
+			router.HandleFunc("/families/members", rest.RegisterInputHandler[CreateMemberRequest](l)(si)("create_member", createMemberHandler(db))).Methods(http.MethodPost)
+			router.HandleFunc("/families/members/{characterId}", rest.RegisterHandler(l)(si)("get_member", getMemberHandler(db))).Methods(http.MethodGet)
+			router.HandleFunc("/families/members/{characterId}/cooldown", rest.RegisterHandler(l)(si)("clear_cooldown", clearCooldownHandler(db))).Methods(http.MethodDelete)
 			router.HandleFunc("/families/{characterId}/juniors", rest.RegisterInputHandler[AddJuniorRequest](l)(si)("add_junior", addJuniorHandler(db))).Methods(http.MethodPost)
 			router.HandleFunc("/families/links/{characterId}", rest.RegisterHandler(l)(si)("break_link", breakLinkHandler(db))).Methods(http.MethodDelete)
+			router.HandleFunc("/families/{characterId}/senior", rest.RegisterHandler(l)(si)("leave_senior", leaveSeniorHandler(db))).Methods(http.MethodDelete)
+			router.HandleFunc("/families/{characterId}/juniors/{juniorId}", rest.RegisterHandler(l)(si)("expel_junior", expelJuniorHandler(db))).Methods(http.MethodDelete)
 			router.HandleFunc("/families/tree/{characterId}", rest.RegisterHandler(l)(si)("get_family_tree", getFamilyTreeHandler(db))).Methods(http.MethodGet)
+			router.HandleFunc("/families/pedigree/{characterId}", rest.RegisterHandler(l)(si)("get_pedigree", getPedigreeHandler(db))).Methods(http.MethodGet)
+			router.HandleFunc("/families/pedigree/{characterId}", rest.RegisterHandler(l)(si)("dissolve_family", dissolveFamilyHandler(db))).Methods(http.MethodDelete)
+
+			// Reputation endpoints
+			router.HandleFunc("/families/{characterId}/reputation", rest.RegisterHandler(l)(si)("get_reputation", getReputationHandler(db))).Methods(http.MethodGet)
+			router.HandleFunc("/families/{characterId}/reputation/awards", rest.RegisterInputHandler[AwardRepRequest](l)(si)("award_reputation", awardRepHandler(db))).Methods(http.MethodPost)
+			router.HandleFunc("/families/{characterId}/reputation/deductions", rest.RegisterInputHandler[DeductRepRequest](l)(si)("deduct_reputation", deductRepHandler(db))).Methods(http.MethodPost)
+			router.HandleFunc("/families/{characterId}/activities", rest.RegisterInputHandler[ActivityRequest](l)(si)("register_activity", registerActivityHandler(db))).Methods(http.MethodPost)
+
+			router.HandleFunc("/families", rest.RegisterHandler(l)(si)("get_family_by_member", getFamilyByMemberHandler(db))).Queries("memberId", "{memberId}").Methods(http.MethodGet)
+			router.HandleFunc("/families/{familyId:[0-9]+}", rest.RegisterHandler(l)(si)("get_family", getFamilyHandler(db))).Methods(http.MethodGet)
+			router.HandleFunc("/families/{familyId:[0-9]+}", rest.RegisterInputHandler[RenameFamilyRequest](l)(si)("rename_family", renameFamilyHandler(db))).Methods(http.MethodPatch)
+			router.HandleFunc("/families/{familyId:[0-9]+}/precept", rest.RegisterInputHandler[UpdateFamilyTextRequest](l)(si)("set_precept", setPreceptHandler(db))).Methods(http.MethodPatch)
+			router.HandleFunc("/families/{familyId:[0-9]+}/notice", rest.RegisterInputHandler[UpdateFamilyTextRequest](l)(si)("set_notice", setNoticeHandler(db))).Methods(http.MethodPatch)
 		}
 	}
 }
@@ -35,30 +57,42 @@ func addJuniorHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.Handl
 			return func(w http.ResponseWriter, r *http.Request) {
 				// Validate request
 				if input.JuniorId == 0 {
-					writeErrorResponse(w, http.StatusBadRequest, "Junior ID is required")
+					rest.WriteErrorResponse(w, http.StatusBadRequest, "Junior ID is required")
 					return
 				}
 
 				if characterId == input.JuniorId {
-					writeErrorResponse(w, http.StatusBadRequest, "Cannot add self as junior")
+					rest.WriteErrorResponse(w, http.StatusBadRequest, "Cannot add self as junior")
 					return
 				}
 
 				// Process the request
-				result, err := NewProcessor(d.Logger(), d.Context(), db).AddJuniorAndEmit(uuid.New(), input.WorldId, characterId, input.SeniorLevel, input.JuniorId, input.JuniorLevel)()
+				fp := NewProcessor(d.Logger(), d.Context(), db)
+				if input.AutoEnroll {
+					fp = fp.WithAutoEnroll()
+				}
+				if input.SeniorMapId != nil {
+					fp = fp.WithLocation(characterId, location.NewModel(input.WorldId, *input.SeniorMapId))
+				}
+				if input.JuniorMapId != nil {
+					fp = fp.WithLocation(input.JuniorId, location.NewModel(input.WorldId, *input.JuniorMapId))
+				}
+				result, err := fp.AddJuniorAndEmit(uuid.New(), input.WorldId, characterId, input.SeniorLevel, input.JuniorId, input.JuniorLevel)()
 				if err != nil {
 					d.Logger().WithError(err).Error("Failed to add junior")
 
 					// Map specific errors to HTTP status codes
 					switch {
 					case errors.Is(err, ErrSeniorNotFound), errors.Is(err, ErrJuniorNotFound), errors.Is(err, ErrMemberNotFound):
-						writeErrorResponse(w, http.StatusNotFound, err.Error())
-					case errors.Is(err, ErrSeniorHasTooManyJuniors), errors.Is(err, ErrJuniorAlreadyLinked), errors.Is(err, ErrLevelDifferenceTooLarge), errors.Is(err, ErrNotOnSameMap):
-						writeErrorResponse(w, http.StatusConflict, err.Error())
-					case errors.Is(err, ErrSelfReference):
-						writeErrorResponse(w, http.StatusBadRequest, err.Error())
+						rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+					case errors.Is(err, ErrSeniorHasTooManyJuniors), errors.Is(err, ErrJuniorAlreadyLinked), errors.Is(err, ErrCooldownActive), errors.Is(err, ErrLevelDifferenceTooLarge), errors.Is(err, ErrNotSameWorld), errors.Is(err, ErrNotOnSameMap), errors.Is(err, ErrCycleDetected), errors.Is(err, database.ErrConflict):
+						rest.WriteErrorResponse(w, http.StatusConflict, err.Error())
+					case errors.Is(err, ErrSelfReference), errors.Is(err, ErrInvalidLevel):
+						rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+					case errors.Is(err, ErrLocationUnavailable):
+						rest.WriteErrorResponse(w, http.StatusServiceUnavailable, err.Error())
 					default:
-						writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+						rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
 					}
 					return
 				}
@@ -67,7 +101,7 @@ func addJuniorHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.Handl
 				restModel, err := Transform(result)
 				if err != nil {
 					d.Logger().WithError(err).Error("Failed to transform family member to REST model")
-					writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+					rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
 					return
 				}
 
@@ -84,22 +118,17 @@ func breakLinkHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.Handl
 	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
 		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
 			return func(w http.ResponseWriter, r *http.Request) {
-				reason := r.URL.Query().Get("reason")
-				if reason == "" {
-					reason = "Member requested link break"
-				}
-
 				// Process the request
-				updatedMembers, err := NewProcessor(d.Logger(), d.Context(), db).BreakLinkAndEmit(uuid.New(), characterId, reason)()
+				updatedMembers, err := NewProcessor(d.Logger(), d.Context(), db).BreakLinkAndEmit(uuid.New(), characterId)()
 				if err != nil {
 					d.Logger().WithError(err).Error("Failed to break family link")
 					switch {
 					case errors.Is(err, ErrMemberNotFound):
-						writeErrorResponse(w, http.StatusNotFound, err.Error())
-					case errors.Is(err, ErrNoLinkToBreak):
-						writeErrorResponse(w, http.StatusConflict, err.Error())
+						rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+					case errors.Is(err, ErrNoLinkToBreak), errors.Is(err, database.ErrConflict):
+						rest.WriteErrorResponse(w, http.StatusConflict, err.Error())
 					default:
-						writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+						rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
 					}
 					return
 				}
@@ -108,7 +137,7 @@ func breakLinkHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.Handl
 				rms, err := model.SliceMap(Transform)(model.FixedProvider(updatedMembers))(model.ParallelMap())()
 				if err != nil {
 					d.Logger().WithError(err).Error("Failed to transform family member to REST model")
-					writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+					rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
 					return
 				}
 
@@ -120,6 +149,74 @@ func breakLinkHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.Handl
 	}
 }
 
+// leaveSeniorHandler handles DELETE /families/{characterId}/senior
+func leaveSeniorHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				updatedMembers, err := NewProcessor(d.Logger(), d.Context(), db).LeaveSeniorAndEmit(uuid.New(), characterId)()
+				writeSeveredLinkResponse(d, c, w, r, updatedMembers, err)
+			}
+		})
+	}
+}
+
+// expelJuniorHandler handles DELETE /families/{characterId}/juniors/{juniorId}
+func expelJuniorHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return rest.ParseJuniorId(d.Logger(), func(juniorId uint32) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					updatedMembers, err := NewProcessor(d.Logger(), d.Context(), db).ExpelJuniorAndEmit(uuid.New(), characterId, juniorId)()
+					writeSeveredLinkResponse(d, c, w, r, updatedMembers, err)
+				}
+			})
+		})
+	}
+}
+
+// dissolveFamilyHandler handles DELETE /families/pedigree/{characterId}
+func dissolveFamilyHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				updatedMembers, err := NewProcessor(d.Logger(), d.Context(), db).DissolveFamilyAndEmit(uuid.New(), characterId)()
+				writeSeveredLinkResponse(d, c, w, r, updatedMembers, err)
+			}
+		})
+	}
+}
+
+// writeSeveredLinkResponse writes the members updated by leaving a senior, expelling a junior or dissolving a family,
+// or maps the error
+func writeSeveredLinkResponse(d *rest.HandlerDependency, c *rest.HandlerContext, w http.ResponseWriter, r *http.Request, updatedMembers []FamilyMember, err error) {
+	if err != nil {
+		d.Logger().WithError(err).Error("Failed to sever family link")
+		switch {
+		case errors.Is(err, ErrMemberNotFound), errors.Is(err, ErrFamilyNotFound):
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrNotFamilyLeader):
+			rest.WriteErrorResponse(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, ErrNoSenior), errors.Is(err, ErrNotJunior), errors.Is(err, ErrInsufficientRep), errors.Is(err, database.ErrConflict):
+			rest.WriteErrorResponse(w, http.StatusConflict, err.Error())
+		default:
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	rms, err := model.SliceMap(Transform)(model.FixedProvider(updatedMembers))(model.ParallelMap())()
+	if err != nil {
+		d.Logger().WithError(err).Error("Failed to transform family member to REST model")
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	query := r.URL.Query()
+	queryParams := jsonapi.ParseQueryFields(&query)
+	server.MarshalResponse[[]RestFamilyMember](d.Logger())(w)(c.ServerInformation())(queryParams)(rms)
+}
+
 // getFamilyTreeHandler handles GET /families/tree/{characterId}
 func getFamilyTreeHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
 	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
@@ -130,9 +227,9 @@ func getFamilyTreeHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.H
 				if err != nil {
 					d.Logger().WithError(err).Error("Failed to get family tree")
 					if errors.Is(err, ErrMemberNotFound) {
-						writeErrorResponse(w, http.StatusNotFound, err.Error())
+						rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 					} else {
-						writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+						rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
 					}
 					return
 				}
@@ -141,7 +238,7 @@ func getFamilyTreeHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.H
 				restTree, err := TransformFamilyTree(familyTree)
 				if err != nil {
 					d.Logger().WithError(err).Error("Failed to transform family tree to REST model")
-					writeErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+					rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
 					return
 				}
 
@@ -153,20 +250,297 @@ func getFamilyTreeHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.H
 	}
 }
 
-// Helper functions for HTTP responses
+// getPedigreeHandler handles GET /families/pedigree/{characterId}
+func getPedigreeHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				// Parse optional generation limit
+				maxDepth := uint64(0)
+				if value := r.URL.Query().Get("maxDepth"); value != "" {
+					var err error
+					maxDepth, err = strconv.ParseUint(value, 10, 32)
+					if err != nil {
+						rest.WriteErrorResponse(w, http.StatusBadRequest, "maxDepth must be a non-negative integer")
+						return
+					}
+				}
+
+				pedigree, err := NewProcessor(d.Logger(), d.Context(), db).GetPedigree(characterId, uint32(maxDepth))
+				if err != nil {
+					d.Logger().WithError(err).Error("Failed to get family pedigree")
+					if errors.Is(err, ErrMemberNotFound) {
+						rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+					} else {
+						rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+					}
+					return
+				}
+
+				restPedigree, err := TransformPedigree(characterId, pedigree)
+				if err != nil {
+					d.Logger().WithError(err).Error("Failed to transform family pedigree to REST model")
+					rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+					return
+				}
+
+				query := r.URL.Query()
+				queryParams := jsonapi.ParseQueryFields(&query)
+				server.MarshalResponse[RestPedigree](d.Logger())(w)(c.ServerInformation())(queryParams)(restPedigree)
+			}
+		})
+	}
+}
+
+// createMemberHandler handles POST /families/members
+func createMemberHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext, input CreateMemberRequest) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext, input CreateMemberRequest) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if input.CharacterId == 0 {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, "Character ID is required")
+				return
+			}
+			if input.Level == 0 {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, "Level is required")
+				return
+			}
+
+			m, err := NewProcessor(d.Logger(), d.Context(), db).CreateMemberAndEmit(uuid.New(), input.World, input.CharacterId, input.Level)()
+			writeMemberResponse(d, c, w, r, m, err)
+		}
+	}
+}
+
+// getMemberHandler handles GET /families/members/{characterId}
+func getMemberHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				m, err := NewProcessor(d.Logger(), d.Context(), db).GetByCharacterId(characterId)
+				writeMemberResponse(d, c, w, r, m, err)
+			}
+		})
+	}
+}
+
+// clearCooldownHandler handles DELETE /families/members/{characterId}/cooldown
+func clearCooldownHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				m, err := NewProcessor(d.Logger(), d.Context(), db).ClearCooldownAndEmit(uuid.New(), characterId)()
+				writeMemberResponse(d, c, w, r, m, err)
+			}
+		})
+	}
+}
 
-// writeErrorResponse writes an error response in JSON format
-func writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
+// getReputationHandler handles GET /families/{characterId}/reputation
+func getReputationHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				m, err := NewProcessor(d.Logger(), d.Context(), db).GetByCharacterId(characterId)
+				writeMemberResponse(d, c, w, r, m, err)
+			}
+		})
+	}
+}
+
+// awardRepHandler handles POST /families/{characterId}/reputation/awards
+func awardRepHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext, input AwardRepRequest) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext, input AwardRepRequest) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if input.Amount == 0 {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, "Amount must be positive")
+					return
+				}
+				if input.Source == "" {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, "Source is required")
+					return
+				}
+
+				m, err := NewProcessor(d.Logger(), d.Context(), db).AwardRepAndEmit(uuid.New(), characterId, input.Amount, input.Source)()
+				writeMemberResponse(d, c, w, r, m, err)
+			}
+		})
+	}
+}
+
+// deductRepHandler handles POST /families/{characterId}/reputation/deductions
+func deductRepHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext, input DeductRepRequest) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext, input DeductRepRequest) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if input.Amount == 0 {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, "Amount must be positive")
+					return
+				}
+				if input.Reason == "" {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, "Reason is required")
+					return
+				}
+
+				m, err := NewProcessor(d.Logger(), d.Context(), db).DeductRepAndEmit(uuid.New(), characterId, input.Amount, input.Reason)()
+				writeMemberResponse(d, c, w, r, m, err)
+			}
+		})
+	}
+}
+
+// registerActivityHandler handles POST /families/{characterId}/activities
+func registerActivityHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext, input ActivityRequest) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext, input ActivityRequest) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if input.Amount == 0 {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, "Amount must be positive")
+					return
+				}
+
+				m, err := NewProcessor(d.Logger(), d.Context(), db).RegisterActivityAndEmit(uuid.New(), characterId, input.ActivityType, input.Amount)()
+				writeMemberResponse(d, c, w, r, m, err)
+			}
+		})
+	}
+}
+
+// getFamilyHandler handles GET /families/{familyId}
+func getFamilyHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseFamilyId(d.Logger(), func(familyId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				f, err := NewProcessor(d.Logger(), d.Context(), db).GetFamilyById(familyId)
+				writeFamilyResponse(d, c, w, r, f, err)
+			}
+		})
+	}
+}
+
+// getFamilyByMemberHandler handles GET /families?memberId={characterId}
+func getFamilyByMemberHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			memberId, err := strconv.ParseUint(r.URL.Query().Get("memberId"), 10, 32)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, "memberId must be a non-negative integer")
+				return
+			}
+
+			f, err := NewProcessor(d.Logger(), d.Context(), db).GetFamilyByMemberId(uint32(memberId))
+			writeFamilyResponse(d, c, w, r, f, err)
+		}
+	}
+}
+
+// renameFamilyHandler handles PATCH /families/{familyId}
+func renameFamilyHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext, input RenameFamilyRequest) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext, input RenameFamilyRequest) http.HandlerFunc {
+		return rest.ParseFamilyId(d.Logger(), func(familyId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if input.CharacterId == 0 {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, "Character ID is required")
+					return
+				}
+
+				f, err := NewProcessor(d.Logger(), d.Context(), db).RenameFamilyAndEmit(uuid.New(), familyId, input.CharacterId, input.Name)()
+				writeFamilyResponse(d, c, w, r, f, err)
+			}
+		})
+	}
+}
+
+// setPreceptHandler handles PATCH /families/{familyId}/precept
+func setPreceptHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext, input UpdateFamilyTextRequest) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext, input UpdateFamilyTextRequest) http.HandlerFunc {
+		return rest.ParseFamilyId(d.Logger(), func(familyId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if input.CharacterId == 0 {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, "Character ID is required")
+					return
+				}
+
+				f, err := NewProcessor(d.Logger(), d.Context(), db).SetPreceptAndEmit(uuid.New(), familyId, input.CharacterId, input.Text)()
+				writeFamilyResponse(d, c, w, r, f, err)
+			}
+		})
+	}
+}
+
+// setNoticeHandler handles PATCH /families/{familyId}/notice
+func setNoticeHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext, input UpdateFamilyTextRequest) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext, input UpdateFamilyTextRequest) http.HandlerFunc {
+		return rest.ParseFamilyId(d.Logger(), func(familyId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if input.CharacterId == 0 {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, "Character ID is required")
+					return
+				}
+
+				f, err := NewProcessor(d.Logger(), d.Context(), db).SetNoticeAndEmit(uuid.New(), familyId, input.CharacterId, input.Text)()
+				writeFamilyResponse(d, c, w, r, f, err)
+			}
+		})
+	}
+}
+
+// writeFamilyResponse maps family lookup errors to HTTP status codes or marshals the family
+func writeFamilyResponse(d *rest.HandlerDependency, c *rest.HandlerContext, w http.ResponseWriter, r *http.Request, f Family, err error) {
+	if err != nil {
+		d.Logger().WithError(err).Error("Failed to process family request")
+		switch {
+		case errors.Is(err, ErrFamilyNotFound), errors.Is(err, ErrMemberNotFound):
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrNotFamilyLeader):
+			rest.WriteErrorResponse(w, http.StatusForbidden, err.Error())
+		case errors.Is(err, ErrInvalidFamilyName), errors.Is(err, ErrFamilyTextTooLong):
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, textfilter.ErrTextRejected):
+			rest.WriteErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+		default:
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
+
+	restFamily, err := TransformFamily(f)
+	if err != nil {
+		d.Logger().WithError(err).Error("Failed to transform family to REST model")
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	query := r.URL.Query()
+	queryParams := jsonapi.ParseQueryFields(&query)
+	server.MarshalResponse[RestFamily](d.Logger())(w)(c.ServerInformation())(queryParams)(restFamily)
+}
+
+// writeMemberResponse writes a family member or maps the error of the member operation
+func writeMemberResponse(d *rest.HandlerDependency, c *rest.HandlerContext, w http.ResponseWriter, r *http.Request, m FamilyMember, err error) {
+	if err != nil {
+		d.Logger().WithError(err).Error("Failed to process family member request")
+		switch {
+		case errors.Is(err, ErrMemberNotFound):
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrInvalidActivityType), errors.Is(err, ErrInvalidCharacterId), errors.Is(err, ErrInvalidLevel):
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, ErrInsufficientRep), errors.Is(err, ErrMemberAlreadyExists), errors.Is(err, database.ErrConflict):
+			rest.WriteErrorResponse(w, http.StatusConflict, err.Error())
+		default:
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		}
+		return
+	}
 
-	errorResponse := map[string]interface{}{
-		"error": map[string]interface{}{
-			"status": statusCode,
-			"title":  http.StatusText(statusCode),
-			"detail": message,
-		},
+	restModel, err := Transform(m)
+	if err != nil {
+		d.Logger().WithError(err).Error("Failed to transform family member to REST model")
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		return
 	}
 
-	json.NewEncoder(w).Encode(errorResponse)
+	query := r.URL.Query()
+	queryParams := jsonapi.ParseQueryFields(&query)
+	server.MarshalResponse[RestFamilyMember](d.Logger())(w)(c.ServerInformation())(queryParams)(restModel)
 }
@@ -9,18 +9,20 @@ import (
 
 // RestFamilyMember represents a family member in REST/JSON:API format
 type RestFamilyMember struct {
-	ID          string   `json:"id"`
-	Type        string   `json:"type"`
-	CharacterId uint32   `json:"characterId"`
-	TenantId    string   `json:"tenantId"`
-	SeniorId    *uint32  `json:"seniorId,omitempty"`
-	JuniorIds   []uint32 `json:"juniorIds"`
-	Rep         uint32   `json:"rep"`
-	DailyRep    uint32   `json:"dailyRep"`
-	Level       uint16   `json:"level"`
-	World       byte     `json:"world"`
-	CreatedAt   string   `json:"createdAt"`
-	UpdatedAt   string   `json:"updatedAt"`
+	ID            string   `json:"id"`
+	Type          string   `json:"type"`
+	CharacterId   uint32   `json:"characterId"`
+	TenantId      string   `json:"tenantId"`
+	SeniorId      *uint32  `json:"seniorId,omitempty"`
+	JuniorIds     []uint32 `json:"juniorIds"`
+	FamilyId      *uint32  `json:"familyId,omitempty"`
+	Rep           uint32   `json:"rep"`
+	DailyRep      uint32   `json:"dailyRep"`
+	Level         uint16   `json:"level"`
+	World         byte     `json:"world"`
+	CooldownUntil *string  `json:"cooldownUntil,omitempty"`
+	CreatedAt     string   `json:"createdAt"`
+	UpdatedAt     string   `json:"updatedAt"`
 }
 
 // GetID returns the ID for JSON:API compatibility
@@ -33,6 +35,11 @@ func (r RestFamilyMember) GetType() string {
 	return "familyMembers"
 }
 
+// GetName returns the resource type for JSON:API compatibility
+func (r RestFamilyMember) GetName() string {
+	return "familyMembers"
+}
+
 // RestFamilyTree represents a complete family tree in REST format
 type RestFamilyTree struct {
 	ID      string             `json:"id"`
@@ -50,25 +57,83 @@ func (r RestFamilyTree) GetType() string {
 	return "familyTrees"
 }
 
+// RestFamily represents a named family aggregate in REST format
+type RestFamily struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	TenantId    string `json:"tenantId"`
+	Name        string `json:"name"`
+	LeaderId    uint32 `json:"leaderId"`
+	MemberCount uint32 `json:"memberCount"`
+	Precept     string `json:"precept"`
+	Notice      string `json:"notice"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r RestFamily) GetID() string {
+	return r.ID
+}
+
+// GetType returns the type for JSON:API compatibility
+func (r RestFamily) GetType() string {
+	return "families"
+}
+
+// RestPedigreeNode represents a member of a pedigree with its position in REST format
+type RestPedigreeNode struct {
+	RestFamilyMember
+	Depth    uint32  `json:"depth"`
+	ParentId *uint32 `json:"parentId"`
+}
+
+// RestPedigree represents a multi-generation pedigree in REST format
+type RestPedigree struct {
+	ID     string             `json:"id"`
+	Type   string             `json:"type"`
+	RootId uint32             `json:"rootId"`
+	Depth  uint32             `json:"depth"`
+	Nodes  []RestPedigreeNode `json:"nodes"`
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r RestPedigree) GetID() string {
+	return r.ID
+}
+
+// GetType returns the type for JSON:API compatibility
+func (r RestPedigree) GetType() string {
+	return "familyPedigrees"
+}
+
 // Transform converts a domain FamilyMember to REST representation
 func Transform(fm FamilyMember) (RestFamilyMember, error) {
 	// Copy junior IDs to avoid shared references
 	juniorIds := make([]uint32, len(fm.JuniorIds()))
 	copy(juniorIds, fm.JuniorIds())
 
+	var cooldownUntil *string
+	if fm.CooldownUntil() != nil {
+		formatted := fm.CooldownUntil().Format(time.RFC3339)
+		cooldownUntil = &formatted
+	}
+
 	return RestFamilyMember{
-		ID:          strconv.FormatUint(uint64(fm.Id()), 10),
-		Type:        "familyMembers",
-		CharacterId: fm.CharacterId(),
-		TenantId:    fm.TenantId().String(),
-		SeniorId:    fm.SeniorId(),
-		JuniorIds:   juniorIds,
-		Rep:         fm.Rep(),
-		DailyRep:    fm.DailyRep(),
-		Level:       fm.Level(),
-		World:       fm.World(),
-		CreatedAt:   fm.CreatedAt().Format(time.RFC3339),
-		UpdatedAt:   fm.UpdatedAt().Format(time.RFC3339),
+		ID:            strconv.FormatUint(uint64(fm.Id()), 10),
+		Type:          "familyMembers",
+		CharacterId:   fm.CharacterId(),
+		TenantId:      fm.TenantId().String(),
+		SeniorId:      fm.SeniorId(),
+		JuniorIds:     juniorIds,
+		FamilyId:      fm.FamilyId(),
+		Rep:           fm.Rep(),
+		DailyRep:      fm.DailyRep(),
+		Level:         fm.Level(),
+		World:         fm.World(),
+		CooldownUntil: cooldownUntil,
+		CreatedAt:     fm.CreatedAt().Format(time.RFC3339),
+		UpdatedAt:     fm.UpdatedAt().Format(time.RFC3339),
 	}, nil
 }
 
@@ -114,6 +179,18 @@ func Extract(r RestFamilyMember) (FamilyMember, error) {
 		builder = builder.SetSeniorId(*r.SeniorId)
 	}
 
+	if r.FamilyId != nil {
+		builder = builder.SetFamilyId(*r.FamilyId)
+	}
+
+	if r.CooldownUntil != nil {
+		cooldownUntil, err := time.Parse(time.RFC3339, *r.CooldownUntil)
+		if err != nil {
+			return FamilyMember{}, err
+		}
+		builder = builder.SetCooldownUntil(cooldownUntil)
+	}
+
 	// Set junior IDs
 	for _, juniorId := range juniorIds {
 		builder = builder.AddJunior(juniorId)
@@ -155,48 +232,184 @@ func TransformFamilyTree(members []FamilyMember) (RestFamilyTree, error) {
 	return TransformTree(members[0].CharacterId(), members)
 }
 
+// TransformPedigree converts a domain Pedigree to REST representation
+func TransformPedigree(characterId uint32, p Pedigree) (RestPedigree, error) {
+	nodes := make([]RestPedigreeNode, 0, len(p.Nodes()))
+	for _, n := range p.Nodes() {
+		restMember, err := Transform(n.Member())
+		if err != nil {
+			return RestPedigree{}, err
+		}
+		nodes = append(nodes, RestPedigreeNode{
+			RestFamilyMember: restMember,
+			Depth:            n.Depth(),
+			ParentId:         n.ParentId(),
+		})
+	}
+
+	return RestPedigree{
+		ID:     strconv.FormatUint(uint64(characterId), 10),
+		Type:   "familyPedigrees",
+		RootId: p.RootId(),
+		Depth:  p.Depth(),
+		Nodes:  nodes,
+	}, nil
+}
+
+// TransformFamily converts a domain Family to REST representation
+func TransformFamily(f Family) (RestFamily, error) {
+	return RestFamily{
+		ID:          strconv.FormatUint(uint64(f.Id()), 10),
+		Type:        "families",
+		TenantId:    f.TenantId().String(),
+		Name:        f.Name(),
+		LeaderId:    f.LeaderId(),
+		MemberCount: f.MemberCount(),
+		Precept:     f.Precept(),
+		Notice:      f.Notice(),
+		CreatedAt:   f.CreatedAt().Format(time.RFC3339),
+		UpdatedAt:   f.UpdatedAt().Format(time.RFC3339),
+	}, nil
+}
+
 // Request structures for JSON:API format
 
 // AddJuniorRequest represents the request body for adding a junior
 type AddJuniorRequest struct {
-	WorldId     byte   `json:"worldId" validate:"required"`
-	SeniorLevel uint16 `json:"seniorLevel" validate:"required"`
-	JuniorId    uint32 `json:"juniorId" validate:"required"`
-	JuniorLevel uint16 `json:"juniorLevel" validate:"required"`
+	WorldId     byte    `json:"worldId" validate:"required"`
+	SeniorLevel uint16  `json:"seniorLevel" validate:"required"`
+	JuniorId    uint32  `json:"juniorId" validate:"required"`
+	JuniorLevel uint16  `json:"juniorLevel" validate:"required"`
+	AutoEnroll  bool    `json:"autoEnroll,omitempty"`
+	SeniorMapId *uint32 `json:"seniorMapId,omitempty"`
+	JuniorMapId *uint32 `json:"juniorMapId,omitempty"`
+}
+
+// CreateMemberRequest represents the request body for enrolling a character as a family member
+type CreateMemberRequest struct {
+	CharacterId uint32 `json:"characterId" validate:"required"`
+	Level       uint16 `json:"level" validate:"required"`
+	World       byte   `json:"world"`
+}
+
+// GetName returns the resource type for JSON:API compatibility
+func (r CreateMemberRequest) GetName() string {
+	return "familyMembers"
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r CreateMemberRequest) GetID() string {
+	return ""
+}
+
+// SetID ignores the client supplied ID, the character is identified by its attributes
+func (r *CreateMemberRequest) SetID(_ string) error {
+	return nil
 }
 
-// BreakLinkRequest represents the request body for breaking a family link
-type BreakLinkRequest struct {
-	Data struct {
-		Type       string `json:"type"`
-		Attributes struct {
-			Reason string `json:"reason,omitempty"`
-		} `json:"attributes"`
-	} `json:"data"`
+// RenameFamilyRequest represents the request body for renaming a family
+type RenameFamilyRequest struct {
+	CharacterId uint32 `json:"characterId" validate:"required"`
+	Name        string `json:"name"`
+}
+
+// GetName returns the resource type for JSON:API compatibility
+func (r RenameFamilyRequest) GetName() string {
+	return "families"
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r RenameFamilyRequest) GetID() string {
+	return ""
+}
+
+// SetID ignores the client supplied ID, the family is identified by the path
+func (r *RenameFamilyRequest) SetID(_ string) error {
+	return nil
+}
+
+// UpdateFamilyTextRequest represents the request body for setting a family precept or notice
+type UpdateFamilyTextRequest struct {
+	CharacterId uint32 `json:"characterId" validate:"required"`
+	Text        string `json:"text"`
+}
+
+// GetName returns the resource type for JSON:API compatibility
+func (r UpdateFamilyTextRequest) GetName() string {
+	return "families"
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r UpdateFamilyTextRequest) GetID() string {
+	return ""
+}
+
+// SetID ignores the client supplied ID, the family is identified by the path
+func (r *UpdateFamilyTextRequest) SetID(_ string) error {
+	return nil
+}
+
+// AwardRepRequest represents the request body for awarding reputation
+type AwardRepRequest struct {
+	Amount uint32 `json:"amount" validate:"required,min=1"`
+	Source string `json:"source" validate:"required"`
+}
+
+// GetName returns the resource type for JSON:API compatibility
+func (r AwardRepRequest) GetName() string {
+	return "reputationAwards"
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r AwardRepRequest) GetID() string {
+	return ""
+}
+
+// SetID ignores the client supplied ID, the member is identified by the path
+func (r *AwardRepRequest) SetID(_ string) error {
+	return nil
 }
 
 // DeductRepRequest represents the request body for deducting reputation
 type DeductRepRequest struct {
-	Data struct {
-		Type       string `json:"type"`
-		Attributes struct {
-			CharacterId uint32 `json:"characterId" validate:"required"`
-			Amount      uint32 `json:"amount" validate:"required,min=1"`
-			Reason      string `json:"reason" validate:"required"`
-		} `json:"attributes"`
-	} `json:"data"`
+	Amount uint32 `json:"amount" validate:"required,min=1"`
+	Reason string `json:"reason" validate:"required"`
+}
+
+// GetName returns the resource type for JSON:API compatibility
+func (r DeductRepRequest) GetName() string {
+	return "reputationDeductions"
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r DeductRepRequest) GetID() string {
+	return ""
+}
+
+// SetID ignores the client supplied ID, the member is identified by the path
+func (r *DeductRepRequest) SetID(_ string) error {
+	return nil
 }
 
 // ActivityRequest represents the request body for registering activity
 type ActivityRequest struct {
-	Data struct {
-		Type       string `json:"type"`
-		Attributes struct {
-			CharacterId  uint32 `json:"characterId" validate:"required"`
-			ActivityType string `json:"activityType" validate:"required,oneof=mob_kill expedition"`
-			Amount       uint32 `json:"amount" validate:"required,min=1"`
-		} `json:"attributes"`
-	} `json:"data"`
+	ActivityType string `json:"activityType" validate:"required,oneof=mob_kill expedition"`
+	Amount       uint32 `json:"amount" validate:"required,min=1"`
+}
+
+// GetName returns the resource type for JSON:API compatibility
+func (r ActivityRequest) GetName() string {
+	return "activities"
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r ActivityRequest) GetID() string {
+	return ""
+}
+
+// SetID ignores the client supplied ID, the member is identified by the path
+func (r *ActivityRequest) SetID(_ string) error {
+	return nil
 }
 
 // Note: These REST models are compatible with JSON:API standards but don't implement
@@ -0,0 +1,200 @@
+package family
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"atlas-family/kafka/message"
+
+	"github.com/google/uuid"
+)
+
+func TestTenantIsolation_CharacterIdUniquePerTenant(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	seedMember(t, db, tenantA, 1000, nil)
+	seedMember(t, db, tenantB, 1000, nil)
+
+	duplicate := Entity{CharacterId: 1000, TenantId: tenantA, Level: 50, World: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := db.Create(&duplicate).Error; err == nil {
+		t.Error("Expected a second member with the same character ID in one tenant to be rejected")
+	}
+}
+
+func TestTenantIsolation_ProvidersScopeByTenant(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantA, tenantB := uuid.New(), uuid.New()
+
+	// Tenant A: 1000 -> 2000 -> 3000. Tenant B: 2000 -> 3000, with 1000 unlinked.
+	seedChain(t, db, tenantA, 1000, 3)
+	seedMember(t, db, tenantB, 1000, nil)
+	seedMember(t, db, tenantB, 2000, nil, 3000)
+	seedMember(t, db, tenantB, 3000, ptr(2000))
+
+	rootA, err := GetRootAncestorProvider(tenantA, 1002)(db)()
+	if err != nil {
+		t.Fatalf("Failed to resolve root in tenant A: %v", err)
+	}
+	if rootA.CharacterId != 1000 || rootA.TenantId != tenantA {
+		t.Errorf("Expected tenant A root 1000, got %d in %v", rootA.CharacterId, rootA.TenantId)
+	}
+	rootB, err := GetRootAncestorProvider(tenantB, 3000)(db)()
+	if err != nil {
+		t.Fatalf("Failed to resolve root in tenant B: %v", err)
+	}
+	if rootB.CharacterId != 2000 || rootB.TenantId != tenantB {
+		t.Errorf("Expected tenant B root 2000, got %d in %v", rootB.CharacterId, rootB.TenantId)
+	}
+
+	descendants, err := GetDescendantsProvider(tenantB, 2000, 0)(db)()
+	if err != nil {
+		t.Fatalf("Failed to get descendants in tenant B: %v", err)
+	}
+	if len(descendants) != 2 {
+		t.Errorf("Expected 2 members below tenant B root, got %d", len(descendants))
+	}
+	for _, e := range descendants {
+		if e.TenantId != tenantB {
+			t.Errorf("Expected only tenant B members, got %d from %v", e.CharacterId, e.TenantId)
+		}
+	}
+
+	ancestry, err := GetAncestryProvider(tenantB, 1000)(db)()
+	if err != nil {
+		t.Fatalf("Failed to get ancestry in tenant B: %v", err)
+	}
+	if len(ancestry) != 0 {
+		t.Errorf("Expected tenant B member 1000 to have no ancestry, got %v", ancestry)
+	}
+
+	other := uuid.New()
+	if exists, err := ExistsProvider(other, 1000)(db)(); err != nil || exists {
+		t.Errorf("Expected member to be absent from an unrelated tenant, got %v, %v", exists, err)
+	}
+	if _, err := GetByCharacterIdProvider(other, 1000)(db)(); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("Expected ErrMemberNotFound in an unrelated tenant, got %v", err)
+	}
+}
+
+func TestTenantIsolation_ProcessorOperations(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	for _, tenantId := range []uuid.UUID{tenantA, tenantB} {
+		seedMember(t, db, tenantId, 1000, nil)
+		seedMember(t, db, tenantId, 2000, nil)
+	}
+	pa := newTestProcessor(t, db, tenantA)
+	pb := newTestProcessor(t, db, tenantB)
+
+	if _, err := pa.AddJunior(nil)(1, 1000, 50, 2000, 50)(); err != nil {
+		t.Fatalf("Failed to add junior in tenant A: %v", err)
+	}
+	assertFamily(t, db, tenantA, 1000, 1000, 2000)
+	if _, err := pb.GetFamilyByMemberId(2000); !errors.Is(err, ErrFamilyNotFound) {
+		t.Errorf("Expected tenant B member to have no family, got %v", err)
+	}
+	juniorB, err := pb.GetByCharacterId(2000)
+	if err != nil {
+		t.Fatalf("Failed to load tenant B member: %v", err)
+	}
+	if juniorB.HasSenior() {
+		t.Errorf("Expected tenant B member to remain unlinked, got senior %d", *juniorB.SeniorId())
+	}
+
+	if _, err := pa.AwardRep(nil)(1000, 300, "QUEST")(); err != nil {
+		t.Fatalf("Failed to award rep in tenant A: %v", err)
+	}
+	seniorB, err := pb.GetByCharacterId(1000)
+	if err != nil {
+		t.Fatalf("Failed to load tenant B member: %v", err)
+	}
+	if seniorB.Rep() != 0 || seniorB.DailyRep() != 0 {
+		t.Errorf("Expected tenant B member rep to be untouched, got %d (daily %d)", seniorB.Rep(), seniorB.DailyRep())
+	}
+
+	// A family ID from one tenant does not resolve in another
+	familyA, err := pa.GetFamilyByMemberId(1000)
+	if err != nil {
+		t.Fatalf("Failed to load tenant A family: %v", err)
+	}
+	if _, err := pb.GetFamilyById(familyA.Id()); !errors.Is(err, ErrFamilyNotFound) {
+		t.Errorf("Expected tenant A family to be invisible to tenant B, got %v", err)
+	}
+	if _, err := pb.RenameFamily(nil)(familyA.Id(), 1000, "Atlas")(); err == nil {
+		t.Error("Expected tenant B to be unable to rename a tenant A family")
+	}
+
+	if _, err := pa.RemoveMember(nil)(2000, "test")(); err != nil {
+		t.Fatalf("Failed to remove member in tenant A: %v", err)
+	}
+	if _, err := pb.GetByCharacterId(2000); err != nil {
+		t.Errorf("Expected tenant B member to survive removal in tenant A, got %v", err)
+	}
+}
+
+func TestTenantIsolation_ResetDailyRep(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	for _, tenantId := range []uuid.UUID{tenantA, tenantB} {
+		seedMember(t, db, tenantId, 1000, nil)
+		if _, err := newTestProcessor(t, db, tenantId).AwardRep(nil)(1000, 100, "QUEST")(); err != nil {
+			t.Fatalf("Failed to award rep: %v", err)
+		}
+	}
+
+	tenantIds, err := GetTenantIdsProvider()(db)()
+	if err != nil {
+		t.Fatalf("Failed to list tenants: %v", err)
+	}
+	if len(tenantIds) != 2 {
+		t.Errorf("Expected 2 tenants with members, got %d", len(tenantIds))
+	}
+
+	result, err := newTestProcessor(t, db, tenantA).ResetDailyRep(message.NewBuffer())()
+	if err != nil {
+		t.Fatalf("Failed to reset daily rep: %v", err)
+	}
+	if result.AffectedCount != 1 {
+		t.Errorf("Expected 1 member reset, got %d", result.AffectedCount)
+	}
+
+	memberB, err := newTestProcessor(t, db, tenantB).GetByCharacterId(1000)
+	if err != nil {
+		t.Fatalf("Failed to load tenant B member: %v", err)
+	}
+	if memberB.DailyRep() != 100 {
+		t.Errorf("Expected tenant B daily rep to be untouched, got %d", memberB.DailyRep())
+	}
+}
+
+func TestMigration_ReplacesGlobalCharacterIndex(t *testing.T) {
+	db := newTestDatabase(t)
+
+	// Recreate the indexes of a schema migrated before uniqueness was scoped by tenant
+	if err := db.Exec("DROP INDEX idx_family_members_tenant_character_unique").Error; err != nil {
+		t.Fatalf("Failed to drop index: %v", err)
+	}
+	if err := db.Exec("CREATE UNIQUE INDEX idx_family_members_character_id ON family_members(character_id)").Error; err != nil {
+		t.Fatalf("Failed to create legacy index: %v", err)
+	}
+	if err := db.Exec("CREATE INDEX idx_family_members_tenant_character ON family_members(tenant_id, character_id)").Error; err != nil {
+		t.Fatalf("Failed to create legacy index: %v", err)
+	}
+
+	if err := Migration(db); err != nil {
+		t.Fatalf("Failed to re-run migration: %v", err)
+	}
+	for _, name := range []string{"idx_family_members_character_id", "idx_family_members_tenant_character"} {
+		if db.Migrator().HasIndex(&Entity{}, name) {
+			t.Errorf("Expected legacy index %s to be dropped", name)
+		}
+	}
+	if !db.Migrator().HasIndex(&Entity{}, "idx_family_members_tenant_character_unique") {
+		t.Error("Expected tenant scoped unique index to be created")
+	}
+
+	seedMember(t, db, uuid.New(), 1000, nil)
+	seedMember(t, db, uuid.New(), 1000, nil)
+}
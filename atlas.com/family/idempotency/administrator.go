@@ -0,0 +1,38 @@
+package idempotency
+
+import (
+	"time"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Record marks a command as processed. It returns false if the command was already recorded.
+func Record(db *gorm.DB, log logrus.FieldLogger) func(tenantId uuid.UUID, transactionId uuid.UUID, commandType string, outcome string, events []Event) model.Provider[bool] {
+	return func(tenantId uuid.UUID, transactionId uuid.UUID, commandType string, outcome string, events []Event) model.Provider[bool] {
+		return func() (bool, error) {
+			log.WithFields(logrus.Fields{
+				"transactionId": transactionId,
+				"commandType":   commandType,
+				"outcome":       outcome,
+			}).Debug("Recording processed command")
+
+			entity := Entity{
+				TenantId:      tenantId,
+				TransactionId: transactionId,
+				CommandType:   commandType,
+				Outcome:       outcome,
+				Events:        events,
+				CreatedAt:     time.Now(),
+			}
+			result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity)
+			if result.Error != nil {
+				return false, result.Error
+			}
+			return result.RowsAffected > 0, nil
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package idempotency
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Entity represents the GORM-compatible database representation of a processed command
+type Entity struct {
+	TenantId      uuid.UUID `gorm:"type:uuid;primaryKey" json:"tenantId"`
+	TransactionId uuid.UUID `gorm:"type:uuid;primaryKey" json:"transactionId"`
+	CommandType   string    `gorm:"primaryKey" json:"commandType"`
+	Outcome       string    `gorm:"not null" json:"outcome"`
+	Events        []Event   `gorm:"serializer:json" json:"events"`
+	CreatedAt     time.Time `gorm:"not null;index" json:"createdAt"`
+}
+
+// TableName specifies the table name for the Entity
+func (Entity) TableName() string {
+	return "family_processed_commands"
+}
+
+// Migration creates the family_processed_commands table
+func Migration(db *gorm.DB) error {
+	return db.AutoMigrate(&Entity{})
+}
+
+// Make transforms an Entity into an immutable Model
+func Make(entity Entity) (Model, error) {
+	return Model{
+		tenantId:      entity.TenantId,
+		transactionId: entity.TransactionId,
+		commandType:   entity.CommandType,
+		outcome:       entity.Outcome,
+		events:        entity.Events,
+		createdAt:     entity.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package idempotency
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+)
+
+// Outcomes of a processed command
+const (
+	OutcomeSucceeded = "SUCCEEDED"
+	OutcomeFailed    = "FAILED"
+)
+
+// ErrDuplicateCommand is returned when a command with the same transaction ID was already processed
+var ErrDuplicateCommand = errors.New("command already processed")
+
+// Event is a result event stored with a processed command so it can be replayed
+type Event struct {
+	Topic string `json:"topic"`
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Model represents an immutable record of a processed command
+type Model struct {
+	tenantId      uuid.UUID
+	transactionId uuid.UUID
+	commandType   string
+	outcome       string
+	events        []Event
+	createdAt     time.Time
+}
+
+func (m Model) TenantId() uuid.UUID {
+	return m.tenantId
+}
+
+func (m Model) TransactionId() uuid.UUID {
+	return m.transactionId
+}
+
+func (m Model) CommandType() string {
+	return m.commandType
+}
+
+func (m Model) Outcome() string {
+	return m.outcome
+}
+
+// Events returns the result events emitted when the command was first processed
+func (m Model) Events() []Event {
+	return m.events
+}
+
+func (m Model) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+// Messages groups the stored result events by topic for replay
+func (m Model) Messages() map[string][]kafka.Message {
+	messages := make(map[string][]kafka.Message)
+	for _, e := range m.events {
+		messages[e.Topic] = append(messages[e.Topic], kafka.Message{Key: e.Key, Value: e.Value})
+	}
+	return messages
+}
+
+// EventsOf flattens buffered messages into storable events, ordered by topic name so replays stage them in the same
+// order as the outbox does, and in buffered order within each topic
+func EventsOf(messages map[string][]kafka.Message) []Event {
+	topics := make([]string, 0, len(messages))
+	for t := range messages {
+		topics = append(topics, t)
+	}
+	sort.Strings(topics)
+
+	events := make([]Event, 0)
+	for _, t := range topics {
+		for _, m := range messages[t] {
+			events = append(events, Event{Topic: t, Key: m.Key, Value: m.Value})
+		}
+	}
+	return events
+}
@@ -0,0 +1,155 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+
+	"atlas-family/database"
+	"atlas-family/kafka/message"
+	"atlas-family/kafka/message/family"
+	"atlas-family/outbox"
+
+	"github.com/Chronicle20/atlas-model/model"
+	tenant "github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ErrCommandNotFound is returned when no processed command is recorded for a transaction
+var ErrCommandNotFound = errors.New("no processed command recorded for transaction")
+
+// Processor interface defines the processed command queries
+type Processor interface {
+	GetByTransactionId(transactionId uuid.UUID) ([]Model, error)
+	Replay(transactionId uuid.UUID) ([]Model, error)
+}
+
+// ProcessorImpl implements the Processor interface
+type ProcessorImpl struct {
+	log logrus.FieldLogger
+	ctx context.Context
+	db  *gorm.DB
+	t   tenant.Model
+}
+
+// NewProcessor creates a new processor instance
+func NewProcessor(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) Processor {
+	return &ProcessorImpl{
+		log: l,
+		ctx: ctx,
+		db:  db,
+		t:   tenant.MustFromContext(ctx),
+	}
+}
+
+// GetByTransactionId returns the commands processed for a transaction
+func (p *ProcessorImpl) GetByTransactionId(transactionId uuid.UUID) ([]Model, error) {
+	ms, err := model.SliceMap(Make)(GetByTransactionIdProvider(p.t.Id(), transactionId)(p.db))(model.ParallelMap())()
+	if err != nil {
+		return nil, err
+	}
+	if len(ms) == 0 {
+		return nil, ErrCommandNotFound
+	}
+	return ms, nil
+}
+
+// Replay re-emits the result events originally produced for every command of a transaction
+func (p *ProcessorImpl) Replay(transactionId uuid.UUID) ([]Model, error) {
+	ms, err := p.GetByTransactionId(transactionId)
+	if err != nil {
+		return nil, err
+	}
+	err = p.db.Transaction(func(tx *gorm.DB) error {
+		for _, m := range ms {
+			p.log.WithFields(logrus.Fields{
+				"transactionId": transactionId,
+				"commandType":   m.CommandType(),
+				"outcome":       m.Outcome(),
+			}).Info("Replaying result events of processed command")
+
+			if _, err := outbox.Enqueue(tx, p.log)(p.ctx, m.Messages())(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ms, nil
+}
+
+// EmitOnce applies a command's business change and stages its buffered events in the outbox at most once per tenant,
+// transaction and command type. A successful change is recorded in the same database transaction as the change
+// itself, so a redelivered command is acknowledged with ErrDuplicateCommand without re-applying it. A rejected command
+// is recorded with its error events once the change has been rolled back. A change which loses an optimistic
+// concurrency check is rolled back and re-applied a bounded number of times. Commands without a transaction ID are
+// never deduplicated.
+func EmitOnce[M any](l logrus.FieldLogger, ctx context.Context, db *gorm.DB) func(transactionId uuid.UUID, commandType string) func(f func(tx *gorm.DB, buf *message.Buffer) (M, error)) (M, error) {
+	return func(transactionId uuid.UUID, commandType string) func(f func(tx *gorm.DB, buf *message.Buffer) (M, error)) (M, error) {
+		return func(f func(tx *gorm.DB, buf *message.Buffer) (M, error)) (M, error) {
+			var result M
+			var err error
+			if transactionId == uuid.Nil {
+				err = database.RetryOnConflict(l, database.ConflictRetries())(func() error {
+					return outbox.Emit(l, ctx, db)(func(tx *gorm.DB, buf *message.Buffer) error {
+						var err error
+						result, err = f(tx, buf)
+						return err
+					})
+				})
+				return result, err
+			}
+
+			fields := logrus.Fields{"transactionId": transactionId, "commandType": commandType}
+			tenantId := tenant.MustFromContext(ctx).Id()
+
+			var errorEvents []kafka.Message
+			err = database.RetryOnConflict(l, database.ConflictRetries())(func() error {
+				errorEvents = nil
+				return outbox.Emit(l, ctx, db)(func(tx *gorm.DB, buf *message.Buffer) error {
+					existing, err := GetProcessedProvider(tenantId, transactionId, commandType)(tx)()
+					if err != nil {
+						return err
+					}
+					if len(existing) > 0 {
+						return ErrDuplicateCommand
+					}
+
+					result, err = f(tx, buf)
+					if err != nil {
+						errorEvents = buf.GetAll()[family.EnvEventTopicErrors]
+						return err
+					}
+
+					recorded, err := Record(tx, l)(tenantId, transactionId, commandType, OutcomeSucceeded, EventsOf(buf.GetAll()))()
+					if err != nil {
+						return err
+					}
+					if !recorded {
+						// A concurrent delivery of the same command won the race
+						return ErrDuplicateCommand
+					}
+					return nil
+				})
+			})
+
+			if errors.Is(err, ErrDuplicateCommand) {
+				l.WithFields(fields).Info("Acknowledging duplicate command without re-applying it")
+				var empty M
+				return empty, err
+			}
+			if err != nil && !errors.Is(err, database.ErrConflict) && len(errorEvents) > 0 {
+				// Only business rejections emit error events; infrastructure failures stay retryable
+				failed := EventsOf(map[string][]kafka.Message{family.EnvEventTopicErrors: errorEvents})
+				if _, rerr := Record(db, l)(tenantId, transactionId, commandType, OutcomeFailed, failed)(); rerr != nil {
+					l.WithError(rerr).WithFields(fields).Warn("Unable to record rejected command")
+				}
+			}
+			return result, err
+		}
+	}
+}
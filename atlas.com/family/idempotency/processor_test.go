@@ -0,0 +1,149 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"atlas-family/database"
+	"atlas-family/kafka/message"
+	"atlas-family/kafka/message/family"
+	"atlas-family/outbox"
+
+	"github.com/Chronicle20/atlas-model/model"
+	tenant "github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDatabase creates an isolated in-memory SQLite database with the processed commands schema
+func newTestDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := Migration(db); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	if err := outbox.Migration(db); err != nil {
+		t.Fatalf("Failed to migrate outbox: %v", err)
+	}
+	return db
+}
+
+// stagedMessages returns the messages staged in the outbox for a topic, in staging order
+func stagedMessages(t *testing.T, db *gorm.DB, topic string) []outbox.Entity {
+	t.Helper()
+	var entities []outbox.Entity
+	if err := db.Where("topic = ?", topic).Order("id").Find(&entities).Error; err != nil {
+		t.Fatalf("Failed to load outbox: %v", err)
+	}
+	return entities
+}
+
+func TestEmitOnce_ReplaysOriginalResult(t *testing.T) {
+	db := newTestDatabase(t)
+	tm, err := tenant.Create(uuid.New(), "GMS", 83, 1)
+	if err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+	ctx := tenant.WithContext(context.Background(), tm)
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+	transactionId := uuid.New()
+
+	applied := 0
+	apply := func(tx *gorm.DB, buf *message.Buffer) (int, error) {
+		applied++
+		return applied, buf.Put(family.EnvEventTopicRep, model.FixedProvider([]kafka.Message{{Key: []byte("1000"), Value: []byte("REP_GAINED")}}))
+	}
+	emitOnce := EmitOnce[int](l, ctx, db)(transactionId, family.CommandTypeAwardRep)
+
+	if _, err := emitOnce(apply); err != nil {
+		t.Fatalf("Failed to apply command: %v", err)
+	}
+	if _, err := emitOnce(apply); !errors.Is(err, ErrDuplicateCommand) {
+		t.Fatalf("Expected ErrDuplicateCommand, got %v", err)
+	}
+	if staged := stagedMessages(t, db, family.EnvEventTopicRep); applied != 1 || len(staged) != 1 {
+		t.Fatalf("Expected the command to be applied and emitted once, got %d applications and %d events", applied, len(staged))
+	}
+
+	p := NewProcessor(l, ctx, db)
+	ms, err := p.Replay(transactionId)
+	if err != nil {
+		t.Fatalf("Failed to replay command: %v", err)
+	}
+	if len(ms) != 1 || ms[0].Outcome() != OutcomeSucceeded {
+		t.Fatalf("Unexpected processed commands: %+v", ms)
+	}
+	events := stagedMessages(t, db, family.EnvEventTopicRep)
+	if len(events) != 2 || string(events[1].MessageKey) != "1000" || string(events[1].Value) != "REP_GAINED" {
+		t.Errorf("Expected the original event to be replayed, got %+v", events)
+	}
+
+	if _, err := p.Replay(uuid.New()); !errors.Is(err, ErrCommandNotFound) {
+		t.Errorf("Expected ErrCommandNotFound, got %v", err)
+	}
+}
+
+func TestEmitOnce_RetriesConflicts(t *testing.T) {
+	db := newTestDatabase(t)
+	tm, err := tenant.Create(uuid.New(), "GMS", 83, 1)
+	if err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+	ctx := tenant.WithContext(context.Background(), tm)
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+	transactionId := uuid.New()
+
+	attempts := 0
+	result, err := EmitOnce[int](l, ctx, db)(transactionId, family.CommandTypeAwardRep)(func(tx *gorm.DB, buf *message.Buffer) (int, error) {
+		attempts++
+		if attempts == 1 {
+			_ = buf.Put(family.EnvEventTopicErrors, model.FixedProvider([]kafka.Message{{Key: []byte("1000"), Value: []byte("REP_ERROR")}}))
+			return 0, database.ErrConflict
+		}
+		return attempts, nil
+	})
+	if err != nil || result != 2 {
+		t.Fatalf("Expected the command to succeed on the second attempt, got %d: %v", result, err)
+	}
+	if staged := stagedMessages(t, db, family.EnvEventTopicErrors); len(staged) != 0 {
+		t.Errorf("Expected no error events from the conflicting attempt, got %d", len(staged))
+	}
+
+	records, err := GetByTransactionIdProvider(tm.Id(), transactionId)(db)()
+	if err != nil {
+		t.Fatalf("Failed to load processed commands: %v", err)
+	}
+	if len(records) != 1 || records[0].Outcome != OutcomeSucceeded {
+		t.Errorf("Expected a single succeeded record, got %+v", records)
+	}
+}
+
+func TestEventsOf_OrdersByTopic(t *testing.T) {
+	messages := map[string][]kafka.Message{
+		"STATUS": {{Value: []byte("a")}, {Value: []byte("b")}},
+		"ERRORS": {{Value: []byte("c")}},
+		"REP":    {{Value: []byte("d")}},
+	}
+
+	// Map iteration order varies between runs, so repeat to catch an unordered flatten
+	for i := 0; i < 20; i++ {
+		events := EventsOf(messages)
+		got := ""
+		for _, e := range events {
+			got += string(e.Value)
+		}
+		if got != "cdab" {
+			t.Fatalf("Expected events ordered by topic then buffered order, got %s", got)
+		}
+	}
+}
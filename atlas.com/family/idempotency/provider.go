@@ -0,0 +1,35 @@
+package idempotency
+
+import (
+	"atlas-family/database"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetProcessedProvider retrieves the processed commands recorded for a transaction and command type
+func GetProcessedProvider(tenantId uuid.UUID, transactionId uuid.UUID, commandType string) database.EntityProvider[[]Entity] {
+	return func(db *gorm.DB) model.Provider[[]Entity] {
+		var entities []Entity
+		if err := db.Where("tenant_id = ? AND transaction_id = ? AND command_type = ?", tenantId, transactionId, commandType).
+			Limit(1).
+			Find(&entities).Error; err != nil {
+			return model.ErrorProvider[[]Entity](err)
+		}
+		return model.FixedProvider(entities)
+	}
+}
+
+// GetByTransactionIdProvider retrieves every processed command recorded for a transaction, oldest first
+func GetByTransactionIdProvider(tenantId uuid.UUID, transactionId uuid.UUID) database.EntityProvider[[]Entity] {
+	return func(db *gorm.DB) model.Provider[[]Entity] {
+		var entities []Entity
+		if err := db.Where("tenant_id = ? AND transaction_id = ?", tenantId, transactionId).
+			Order("created_at").
+			Find(&entities).Error; err != nil {
+			return model.ErrorProvider[[]Entity](err)
+		}
+		return model.FixedProvider(entities)
+	}
+}
@@ -0,0 +1,86 @@
+package idempotency
+
+import (
+	"atlas-family/rest"
+	"errors"
+	"net/http"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-rest/server"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/jtumidanski/api2go/jsonapi"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// InitResource registers all processed command REST endpoints
+func InitResource(si jsonapi.ServerInformation) func(db *gorm.DB) server.RouteInitializer {
+	return func(db *gorm.DB) server.RouteInitializer {
+		return func(router *mux.Router, l logrus.FieldLogger) {
+			router.HandleFunc("/families/commands/{transactionId}", rest.RegisterHandler(l)(si)("get_processed_commands", getProcessedCommandsHandler(db))).Methods(http.MethodGet)
+			router.HandleFunc("/families/commands/{transactionId}/replay", rest.RegisterHandler(l)(si)("replay_processed_commands", replayProcessedCommandsHandler(db))).Methods(http.MethodPost)
+		}
+	}
+}
+
+// getProcessedCommandsHandler handles GET /families/commands/{transactionId}
+func getProcessedCommandsHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return parseTransactionId(d.Logger(), func(transactionId uuid.UUID) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				ms, err := NewProcessor(d.Logger(), d.Context(), db).GetByTransactionId(transactionId)
+				writeResponse(d, c, w, r, ms, err)
+			}
+		})
+	}
+}
+
+// replayProcessedCommandsHandler handles POST /families/commands/{transactionId}/replay
+func replayProcessedCommandsHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return parseTransactionId(d.Logger(), func(transactionId uuid.UUID) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				ms, err := NewProcessor(d.Logger(), d.Context(), db).Replay(transactionId)
+				writeResponse(d, c, w, r, ms, err)
+			}
+		})
+	}
+}
+
+// parseTransactionId reads the transaction ID path variable
+func parseTransactionId(l logrus.FieldLogger, next func(transactionId uuid.UUID) http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		transactionId, err := uuid.Parse(mux.Vars(r)["transactionId"])
+		if err != nil {
+			l.WithError(err).Errorf("Unable to properly parse transactionId from path.")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		next(transactionId)(w, r)
+	}
+}
+
+// writeResponse marshals processed commands, mapping processor errors to HTTP status codes
+func writeResponse(d *rest.HandlerDependency, c *rest.HandlerContext, w http.ResponseWriter, r *http.Request, ms []Model, err error) {
+	if err != nil {
+		if errors.Is(err, ErrCommandNotFound) {
+			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+			return
+		}
+		d.Logger().WithError(err).Error("Failed to get processed commands")
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	rm, err := model.SliceMap(Transform)(model.FixedProvider(ms))(model.ParallelMap())()
+	if err != nil {
+		d.Logger().WithError(err).Error("Failed to transform processed commands to REST model")
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	query := r.URL.Query()
+	queryParams := jsonapi.ParseQueryFields(&query)
+	server.MarshalResponse[[]RestModel](d.Logger())(w)(c.ServerInformation())(queryParams)(rm)
+}
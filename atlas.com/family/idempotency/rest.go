@@ -0,0 +1,48 @@
+package idempotency
+
+import (
+	"time"
+)
+
+// RestEvent represents a stored result event in REST format
+type RestEvent struct {
+	Topic string `json:"topic"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// RestModel represents a processed command in REST/JSON:API format
+type RestModel struct {
+	Id            string      `json:"-"`
+	TransactionId string      `json:"transactionId"`
+	CommandType   string      `json:"commandType"`
+	Outcome       string      `json:"outcome"`
+	Events        []RestEvent `json:"events"`
+	CreatedAt     string      `json:"createdAt"`
+}
+
+// GetName returns the resource type for JSON:API compatibility
+func (r RestModel) GetName() string {
+	return "familyProcessedCommands"
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r RestModel) GetID() string {
+	return r.Id
+}
+
+// Transform converts a domain model to REST representation
+func Transform(m Model) (RestModel, error) {
+	events := make([]RestEvent, 0, len(m.Events()))
+	for _, e := range m.Events() {
+		events = append(events, RestEvent{Topic: e.Topic, Key: string(e.Key), Value: string(e.Value)})
+	}
+	return RestModel{
+		Id:            m.TransactionId().String() + ":" + m.CommandType(),
+		TransactionId: m.TransactionId().String(),
+		CommandType:   m.CommandType(),
+		Outcome:       m.Outcome(),
+		Events:        events,
+		CreatedAt:     m.CreatedAt().Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,64 @@
+package invitation
+
+import (
+	"time"
+
+	"github.com/Chronicle20/atlas-model/model"
+	tenant "github.com/Chronicle20/atlas-tenant"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// CreateInvitation persists a new pending invitation for the tenant
+func CreateInvitation(db *gorm.DB, log logrus.FieldLogger) func(t tenant.Model, m Model) model.Provider[Entity] {
+	return func(t tenant.Model, m Model) model.Provider[Entity] {
+		log.WithFields(logrus.Fields{
+			"seniorId":  m.SeniorId(),
+			"juniorId":  m.JuniorId(),
+			"expiresAt": m.ExpiresAt(),
+		}).Debug("Creating family invitation")
+
+		entity := Entity{
+			TenantId:     t.Id(),
+			Region:       t.Region(),
+			MajorVersion: t.MajorVersion(),
+			MinorVersion: t.MinorVersion(),
+			WorldId:      m.WorldId(),
+			SeniorId:     m.SeniorId(),
+			SeniorLevel:  m.SeniorLevel(),
+			JuniorId:     m.JuniorId(),
+			JuniorLevel:  m.JuniorLevel(),
+			Status:       m.Status(),
+			ExpiresAt:    m.ExpiresAt(),
+			CreatedAt:    m.CreatedAt(),
+			UpdatedAt:    m.UpdatedAt(),
+		}
+		if err := db.Create(&entity).Error; err != nil {
+			return model.ErrorProvider[Entity](err)
+		}
+		return model.FixedProvider(entity)
+	}
+}
+
+// UpdateStatus transitions a pending invitation to a new status
+func UpdateStatus(db *gorm.DB, log logrus.FieldLogger) func(id uint32, status string) model.Provider[bool] {
+	return func(id uint32, status string) model.Provider[bool] {
+		return func() (bool, error) {
+			log.WithFields(logrus.Fields{
+				"invitationId": id,
+				"status":       status,
+			}).Debug("Updating family invitation status")
+
+			result := db.Model(&Entity{}).
+				Where("id = ? AND status = ?", id, StatusPending).
+				Updates(map[string]interface{}{
+					"status":     status,
+					"updated_at": time.Now(),
+				})
+			if result.Error != nil {
+				return false, result.Error
+			}
+			return result.RowsAffected > 0, nil
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package invitation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Builder constructs immutable invitation models
+type Builder struct {
+	id          uint32
+	tenantId    uuid.UUID
+	worldId     byte
+	seniorId    uint32
+	seniorLevel uint16
+	juniorId    uint32
+	juniorLevel uint16
+	status      string
+	expiresAt   time.Time
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// NewBuilder creates a new pending invitation builder with required parameters
+func NewBuilder(tenantId uuid.UUID, seniorId uint32, juniorId uint32, expiresAt time.Time) *Builder {
+	return &Builder{
+		tenantId:  tenantId,
+		seniorId:  seniorId,
+		juniorId:  juniorId,
+		status:    StatusPending,
+		expiresAt: expiresAt,
+		createdAt: time.Now(),
+		updatedAt: time.Now(),
+	}
+}
+
+// Fluent setters for optional parameters
+
+func (b *Builder) SetId(id uint32) *Builder {
+	b.id = id
+	return b
+}
+
+func (b *Builder) SetWorldId(worldId byte) *Builder {
+	b.worldId = worldId
+	return b
+}
+
+func (b *Builder) SetSeniorLevel(seniorLevel uint16) *Builder {
+	b.seniorLevel = seniorLevel
+	return b
+}
+
+func (b *Builder) SetJuniorLevel(juniorLevel uint16) *Builder {
+	b.juniorLevel = juniorLevel
+	return b
+}
+
+func (b *Builder) SetStatus(status string) *Builder {
+	b.status = status
+	return b
+}
+
+func (b *Builder) SetCreatedAt(createdAt time.Time) *Builder {
+	b.createdAt = createdAt
+	return b
+}
+
+func (b *Builder) SetUpdatedAt(updatedAt time.Time) *Builder {
+	b.updatedAt = updatedAt
+	return b
+}
+
+func (b *Builder) Touch() *Builder {
+	b.updatedAt = time.Now()
+	return b
+}
+
+// Build validates business rules and constructs the final immutable Model
+func (b *Builder) Build() (Model, error) {
+	if b.seniorId == 0 || b.juniorId == 0 {
+		return Model{}, ErrInvalidCharacterId
+	}
+
+	if b.tenantId == uuid.Nil {
+		return Model{}, ErrInvalidTenantId
+	}
+
+	if b.seniorId == b.juniorId {
+		return Model{}, ErrSelfInvitation
+	}
+
+	if err := ValidateStatus(b.status); err != nil {
+		return Model{}, err
+	}
+
+	return Model{
+		id:          b.id,
+		tenantId:    b.tenantId,
+		worldId:     b.worldId,
+		seniorId:    b.seniorId,
+		seniorLevel: b.seniorLevel,
+		juniorId:    b.juniorId,
+		juniorLevel: b.juniorLevel,
+		status:      b.status,
+		expiresAt:   b.expiresAt,
+		createdAt:   b.createdAt,
+		updatedAt:   b.updatedAt,
+	}, nil
+}
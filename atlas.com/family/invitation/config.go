@@ -0,0 +1,23 @@
+package invitation
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// EnvExpirySeconds configures how long an invitation remains open
+const EnvExpirySeconds = "INVITATION_EXPIRY_SECONDS"
+
+// DefaultExpiry is used when no expiry is configured
+const DefaultExpiry = 5 * time.Minute
+
+// Expiry returns the configured invitation lifetime
+func Expiry() time.Duration {
+	if value, ok := os.LookupEnv(EnvExpirySeconds); ok {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return DefaultExpiry
+}
@@ -0,0 +1,49 @@
+package invitation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Entity represents the GORM-compatible database representation of an invitation
+type Entity struct {
+	ID           uint32    `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantId     uuid.UUID `gorm:"type:uuid;not null;index" json:"tenantId"`
+	Region       string    `gorm:"not null" json:"region"`
+	MajorVersion uint16    `gorm:"not null" json:"majorVersion"`
+	MinorVersion uint16    `gorm:"not null" json:"minorVersion"`
+	WorldId      byte      `gorm:"not null" json:"worldId"`
+	SeniorId     uint32    `gorm:"not null;index" json:"seniorId"`
+	SeniorLevel  uint16    `gorm:"not null" json:"seniorLevel"`
+	JuniorId     uint32    `gorm:"not null;index" json:"juniorId"`
+	JuniorLevel  uint16    `gorm:"not null" json:"juniorLevel"`
+	Status       string    `gorm:"not null;index" json:"status"`
+	ExpiresAt    time.Time `gorm:"not null;index" json:"expiresAt"`
+	CreatedAt    time.Time `gorm:"not null" json:"createdAt"`
+	UpdatedAt    time.Time `gorm:"not null" json:"updatedAt"`
+}
+
+// TableName specifies the table name for the Entity
+func (Entity) TableName() string {
+	return "family_invitations"
+}
+
+// Migration creates the family_invitations table
+func Migration(db *gorm.DB) error {
+	return db.AutoMigrate(&Entity{})
+}
+
+// Make transforms an Entity into an immutable Model
+func Make(entity Entity) (Model, error) {
+	return NewBuilder(entity.TenantId, entity.SeniorId, entity.JuniorId, entity.ExpiresAt).
+		SetId(entity.ID).
+		SetWorldId(entity.WorldId).
+		SetSeniorLevel(entity.SeniorLevel).
+		SetJuniorLevel(entity.JuniorLevel).
+		SetStatus(entity.Status).
+		SetCreatedAt(entity.CreatedAt).
+		SetUpdatedAt(entity.UpdatedAt).
+		Build()
+}
@@ -0,0 +1,122 @@
+package invitation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Invitation statuses
+const (
+	StatusPending  = "PENDING"
+	StatusAccepted = "ACCEPTED"
+	StatusDeclined = "DECLINED"
+	StatusExpired  = "EXPIRED"
+)
+
+// Model represents an immutable invitation from a senior to a prospective junior
+type Model struct {
+	id          uint32
+	tenantId    uuid.UUID
+	worldId     byte
+	seniorId    uint32
+	seniorLevel uint16
+	juniorId    uint32
+	juniorLevel uint16
+	status      string
+	expiresAt   time.Time
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// Accessor methods for Model
+func (m Model) Id() uint32 {
+	return m.id
+}
+
+func (m Model) TenantId() uuid.UUID {
+	return m.tenantId
+}
+
+func (m Model) WorldId() byte {
+	return m.worldId
+}
+
+func (m Model) SeniorId() uint32 {
+	return m.seniorId
+}
+
+func (m Model) SeniorLevel() uint16 {
+	return m.seniorLevel
+}
+
+func (m Model) JuniorId() uint32 {
+	return m.juniorId
+}
+
+func (m Model) JuniorLevel() uint16 {
+	return m.juniorLevel
+}
+
+func (m Model) Status() string {
+	return m.status
+}
+
+func (m Model) ExpiresAt() time.Time {
+	return m.expiresAt
+}
+
+func (m Model) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+func (m Model) UpdatedAt() time.Time {
+	return m.updatedAt
+}
+
+// Business logic methods
+
+// IsPending returns true if the invitation is still awaiting a response
+func (m Model) IsPending() bool {
+	return m.status == StatusPending
+}
+
+// IsExpired returns true if the invitation's expiry has passed at the given time
+func (m Model) IsExpired(now time.Time) bool {
+	return !now.Before(m.expiresAt)
+}
+
+// Builder returns a new builder for modification
+func (m Model) Builder() *Builder {
+	return &Builder{
+		id:          m.id,
+		tenantId:    m.tenantId,
+		worldId:     m.worldId,
+		seniorId:    m.seniorId,
+		seniorLevel: m.seniorLevel,
+		juniorId:    m.juniorId,
+		juniorLevel: m.juniorLevel,
+		status:      m.status,
+		expiresAt:   m.expiresAt,
+		createdAt:   m.createdAt,
+		updatedAt:   m.updatedAt,
+	}
+}
+
+// Validation errors
+var (
+	ErrInvalidCharacterId = errors.New("invalid character ID")
+	ErrInvalidTenantId    = errors.New("invalid tenant ID")
+	ErrSelfInvitation     = errors.New("cannot invite self as junior")
+	ErrInvalidStatus      = errors.New("invalid invitation status")
+)
+
+// ValidateStatus validates an invitation status
+func ValidateStatus(status string) error {
+	switch status {
+	case StatusPending, StatusAccepted, StatusDeclined, StatusExpired:
+		return nil
+	}
+	return ErrInvalidStatus
+}
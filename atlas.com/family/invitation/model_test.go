@@ -0,0 +1,57 @@
+package invitation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestModel_IsExpired(t *testing.T) {
+	now := time.Now()
+
+	m, err := NewBuilder(uuid.New(), 1000, 2000, now.Add(time.Minute)).Build()
+	if err != nil {
+		t.Fatalf("Failed to build invitation: %v", err)
+	}
+
+	if m.IsExpired(now) {
+		t.Error("Invitation should not be expired before its deadline")
+	}
+
+	if !m.IsExpired(now.Add(2 * time.Minute)) {
+		t.Error("Invitation should be expired after its deadline")
+	}
+
+	if !m.IsPending() {
+		t.Error("New invitation should be pending")
+	}
+}
+
+func TestBuilder_Validation(t *testing.T) {
+	tenantId := uuid.New()
+	expiresAt := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name     string
+		builder  *Builder
+		expected error
+	}{
+		{"Valid invitation", NewBuilder(tenantId, 1000, 2000, expiresAt), nil},
+		{"Missing senior", NewBuilder(tenantId, 0, 2000, expiresAt), ErrInvalidCharacterId},
+		{"Missing junior", NewBuilder(tenantId, 1000, 0, expiresAt), ErrInvalidCharacterId},
+		{"Missing tenant", NewBuilder(uuid.Nil, 1000, 2000, expiresAt), ErrInvalidTenantId},
+		{"Self invitation", NewBuilder(tenantId, 1000, 1000, expiresAt), ErrSelfInvitation},
+		{"Unknown status", NewBuilder(tenantId, 1000, 2000, expiresAt).SetStatus("UNKNOWN"), ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Build() error = %v, want %v", err, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"atlas-family/family"
+	"atlas-family/idempotency"
+	"atlas-family/kafka/message"
+	familymsg "atlas-family/kafka/message/family"
+	"atlas-family/location"
+	"atlas-family/outbox"
+
+	"github.com/Chronicle20/atlas-model/model"
+	tenant "github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Processor interface defines the invitation lifecycle operations
+type Processor interface {
+	WithTransaction(db *gorm.DB) Processor
+	Create(buf *message.Buffer) func(worldId byte, seniorId uint32, seniorLevel uint16, juniorId uint32, juniorLevel uint16) model.Provider[Model]
+	Accept(buf *message.Buffer) func(juniorId uint32, invitationId uint32) model.Provider[Model]
+	Decline(buf *message.Buffer) func(juniorId uint32, invitationId uint32) model.Provider[Model]
+	Expire(buf *message.Buffer) func(invitationId uint32) model.Provider[Model]
+
+	// AndEmit variants for Kafka message emission
+	CreateAndEmit(transactionId uuid.UUID, worldId byte, seniorId uint32, seniorLevel uint16, juniorId uint32, juniorLevel uint16) model.Provider[Model]
+	AcceptAndEmit(transactionId uuid.UUID, juniorId uint32, invitationId uint32) model.Provider[Model]
+	DeclineAndEmit(transactionId uuid.UUID, juniorId uint32, invitationId uint32) model.Provider[Model]
+
+	GetById(invitationId uint32) (Model, error)
+	GetPendingByCharacterId(characterId uint32) ([]Model, error)
+}
+
+// ProcessorImpl implements the Processor interface
+type ProcessorImpl struct {
+	log       logrus.FieldLogger
+	ctx       context.Context
+	db        *gorm.DB
+	t         tenant.Model
+	locations map[uint32]location.Model
+}
+
+// NewProcessor creates a new processor instance
+func NewProcessor(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) Processor {
+	return &ProcessorImpl{
+		log: l,
+		ctx: ctx,
+		db:  db,
+		t:   tenant.MustFromContext(ctx),
+	}
+}
+
+// Business logic errors
+var (
+	ErrInvitationNotFound       = errors.New("family invitation not found")
+	ErrInvitationNotPending     = errors.New("family invitation is no longer pending")
+	ErrInvitationExpired        = errors.New("family invitation has expired")
+	ErrInvitationAlreadyPending = errors.New("a pending family invitation already exists for this pair")
+)
+
+func (p *ProcessorImpl) WithTransaction(db *gorm.DB) Processor {
+	return &ProcessorImpl{
+		log:       p.log,
+		ctx:       p.ctx,
+		db:        db,
+		t:         p.t,
+		locations: p.locations,
+	}
+}
+
+// Create records a pending invitation from a senior to a prospective junior
+func (p *ProcessorImpl) Create(buf *message.Buffer) func(worldId byte, seniorId uint32, seniorLevel uint16, juniorId uint32, juniorLevel uint16) model.Provider[Model] {
+	return func(worldId byte, seniorId uint32, seniorLevel uint16, juniorId uint32, juniorLevel uint16) model.Provider[Model] {
+		return func() (Model, error) {
+			p.log.WithFields(logrus.Fields{
+				"seniorId": seniorId,
+				"juniorId": juniorId,
+			}).Info("Creating family invitation")
+
+			if seniorId == juniorId {
+				p.putError(buf, worldId, seniorId, seniorId, juniorId, "SELF_REFERENCE", ErrSelfInvitation)
+				return Model{}, ErrSelfInvitation
+			}
+
+			pending, err := GetPendingByPairProvider(p.t.Id(), seniorId, juniorId)(p.db)()
+			if err != nil {
+				return Model{}, err
+			}
+			if len(pending) > 0 {
+				p.putError(buf, worldId, seniorId, seniorId, juniorId, "INVITATION_ALREADY_PENDING", ErrInvitationAlreadyPending)
+				return Model{}, ErrInvitationAlreadyPending
+			}
+
+			m, err := NewBuilder(p.t.Id(), seniorId, juniorId, time.Now().Add(Expiry())).
+				SetWorldId(worldId).
+				SetSeniorLevel(seniorLevel).
+				SetJuniorLevel(juniorLevel).
+				Build()
+			if err != nil {
+				return Model{}, err
+			}
+
+			result, err := model.Map(Make)(CreateInvitation(p.db, p.log)(p.t, m))()
+			if err != nil {
+				return Model{}, err
+			}
+
+			if buf != nil {
+				if putErr := buf.Put(familymsg.EnvEventTopicStatus, CreatedEventProvider(result)); putErr != nil {
+					p.log.WithError(putErr).Error("Failed to add invitation created event to buffer")
+				}
+			}
+			return result, nil
+		}
+	}
+}
+
+// Accept links the junior to the senior, re-running every AddJunior validation at accept time
+func (p *ProcessorImpl) Accept(buf *message.Buffer) func(juniorId uint32, invitationId uint32) model.Provider[Model] {
+	return func(juniorId uint32, invitationId uint32) model.Provider[Model] {
+		return func() (Model, error) {
+			p.log.WithFields(logrus.Fields{
+				"juniorId":     juniorId,
+				"invitationId": invitationId,
+			}).Info("Accepting family invitation")
+
+			m, err := p.getRespondable(buf, juniorId, invitationId)
+			if err != nil {
+				return Model{}, err
+			}
+
+			var result Model
+			err = p.db.Transaction(func(tx *gorm.DB) error {
+				fp := family.NewProcessor(p.log, p.ctx, tx)
+				for characterId, lm := range p.locations {
+					fp = fp.WithLocation(characterId, lm)
+				}
+				seniorLevel := currentLevel(fp, m.SeniorId(), m.SeniorLevel())
+				juniorLevel := currentLevel(fp, juniorId, m.JuniorLevel())
+				if _, err := fp.AddJunior(buf)(m.WorldId(), m.SeniorId(), seniorLevel, juniorId, juniorLevel)(); err != nil {
+					return err
+				}
+
+				result, err = p.transition(tx, m, StatusAccepted)
+				return err
+			})
+			if err != nil {
+				return Model{}, err
+			}
+
+			if buf != nil {
+				if putErr := buf.Put(familymsg.EnvEventTopicStatus, AcceptedEventProvider(result)); putErr != nil {
+					p.log.WithError(putErr).Error("Failed to add invitation accepted event to buffer")
+				}
+			}
+			return result, nil
+		}
+	}
+}
+
+// Decline closes the invitation without linking the characters
+func (p *ProcessorImpl) Decline(buf *message.Buffer) func(juniorId uint32, invitationId uint32) model.Provider[Model] {
+	return func(juniorId uint32, invitationId uint32) model.Provider[Model] {
+		return func() (Model, error) {
+			p.log.WithFields(logrus.Fields{
+				"juniorId":     juniorId,
+				"invitationId": invitationId,
+			}).Info("Declining family invitation")
+
+			m, err := p.getRespondable(buf, juniorId, invitationId)
+			if err != nil {
+				return Model{}, err
+			}
+
+			result, err := p.transition(p.db, m, StatusDeclined)
+			if err != nil {
+				return Model{}, err
+			}
+
+			if buf != nil {
+				if putErr := buf.Put(familymsg.EnvEventTopicStatus, DeclinedEventProvider(result)); putErr != nil {
+					p.log.WithError(putErr).Error("Failed to add invitation declined event to buffer")
+				}
+			}
+			return result, nil
+		}
+	}
+}
+
+// Expire closes a pending invitation whose expiry has passed
+func (p *ProcessorImpl) Expire(buf *message.Buffer) func(invitationId uint32) model.Provider[Model] {
+	return func(invitationId uint32) model.Provider[Model] {
+		return func() (Model, error) {
+			m, err := p.GetById(invitationId)
+			if err != nil {
+				return Model{}, err
+			}
+			if !m.IsPending() {
+				return Model{}, ErrInvitationNotPending
+			}
+
+			result, err := p.transition(p.db, m, StatusExpired)
+			if err != nil {
+				return Model{}, err
+			}
+
+			if buf != nil {
+				if putErr := buf.Put(familymsg.EnvEventTopicStatus, ExpiredEventProvider(result)); putErr != nil {
+					p.log.WithError(putErr).Error("Failed to add invitation expired event to buffer")
+				}
+			}
+			return result, nil
+		}
+	}
+}
+
+// currentLevel returns the character's level as a family member, falling back to the level recorded when the
+// invitation was created for a character who is not a member
+func currentLevel(fp family.Processor, characterId uint32, recorded uint16) uint16 {
+	fm, err := fp.GetByCharacterId(characterId)
+	if err != nil {
+		return recorded
+	}
+	return fm.Level()
+}
+
+// withResolvedLocations returns a processor which links the invitation's characters at locations resolved up front, so
+// the character service is not called while the accept transaction is held. A character whose location cannot be
+// resolved here is looked up again, and reported, when the link is made.
+func (p *ProcessorImpl) withResolvedLocations(juniorId uint32, invitationId uint32) *ProcessorImpl {
+	m, err := p.GetById(invitationId)
+	if err != nil {
+		return p
+	}
+	locations := make(map[uint32]location.Model)
+	for _, characterId := range []uint32{m.SeniorId(), juniorId} {
+		lm, err := location.Default().GetLocation(p.log, p.ctx, characterId)
+		if err != nil {
+			p.log.WithError(err).WithField("characterId", characterId).Debug("Unable to resolve location before accepting invitation")
+			continue
+		}
+		locations[characterId] = lm
+	}
+	return &ProcessorImpl{
+		log:       p.log,
+		ctx:       p.ctx,
+		db:        p.db,
+		t:         p.t,
+		locations: locations,
+	}
+}
+
+// getRespondable loads an invitation addressed to the junior which can still be accepted or declined
+func (p *ProcessorImpl) getRespondable(buf *message.Buffer, juniorId uint32, invitationId uint32) (Model, error) {
+	m, err := p.GetById(invitationId)
+	if err != nil || m.JuniorId() != juniorId {
+		if err == nil || errors.Is(err, ErrInvitationNotFound) {
+			p.putError(buf, 0, juniorId, 0, juniorId, "INVITATION_NOT_FOUND", ErrInvitationNotFound)
+			return Model{}, ErrInvitationNotFound
+		}
+		return Model{}, err
+	}
+
+	if !m.IsPending() {
+		p.putError(buf, m.WorldId(), juniorId, m.SeniorId(), juniorId, "INVITATION_NOT_PENDING", ErrInvitationNotPending)
+		return Model{}, ErrInvitationNotPending
+	}
+
+	if m.IsExpired(time.Now()) {
+		p.putError(buf, m.WorldId(), juniorId, m.SeniorId(), juniorId, "INVITATION_EXPIRED", ErrInvitationExpired)
+		return Model{}, ErrInvitationExpired
+	}
+	return m, nil
+}
+
+// transition moves a pending invitation to its final status, failing if another response won the race
+func (p *ProcessorImpl) transition(db *gorm.DB, m Model, status string) (Model, error) {
+	updated, err := UpdateStatus(db, p.log)(m.Id(), status)()
+	if err != nil {
+		return Model{}, err
+	}
+	if !updated {
+		return Model{}, ErrInvitationNotPending
+	}
+	return m.Builder().SetStatus(status).Touch().Build()
+}
+
+func (p *ProcessorImpl) putError(buf *message.Buffer, worldId byte, characterId uint32, seniorId uint32, juniorId uint32, errorCode string, err error) {
+	if buf == nil {
+		return
+	}
+	if putErr := buf.Put(familymsg.EnvEventTopicErrors, family.LinkErrorEventProvider(worldId, characterId, seniorId, juniorId, errorCode, err.Error())); putErr != nil {
+		p.log.WithError(putErr).Error("Failed to add link error event to buffer")
+	}
+}
+
+// AndEmit variants - combine business logic with event emission. Each runs at most once per transaction ID.
+
+// CreateAndEmit creates an invitation and emits appropriate events
+func (p *ProcessorImpl) CreateAndEmit(transactionId uuid.UUID, worldId byte, seniorId uint32, seniorLevel uint16, juniorId uint32, juniorLevel uint16) model.Provider[Model] {
+	return func() (Model, error) {
+		return idempotency.EmitOnce[Model](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeInviteJunior)(func(tx *gorm.DB, buf *message.Buffer) (Model, error) {
+			return p.WithTransaction(tx).Create(buf)(worldId, seniorId, seniorLevel, juniorId, juniorLevel)()
+		})
+	}
+}
+
+// AcceptAndEmit accepts an invitation and emits appropriate events
+func (p *ProcessorImpl) AcceptAndEmit(transactionId uuid.UUID, juniorId uint32, invitationId uint32) model.Provider[Model] {
+	return func() (Model, error) {
+		rp := p.withResolvedLocations(juniorId, invitationId)
+		return idempotency.EmitOnce[Model](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeAcceptInvitation)(func(tx *gorm.DB, buf *message.Buffer) (Model, error) {
+			return rp.WithTransaction(tx).Accept(buf)(juniorId, invitationId)()
+		})
+	}
+}
+
+// DeclineAndEmit declines an invitation and emits appropriate events
+func (p *ProcessorImpl) DeclineAndEmit(transactionId uuid.UUID, juniorId uint32, invitationId uint32) model.Provider[Model] {
+	return func() (Model, error) {
+		return idempotency.EmitOnce[Model](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeDeclineInvitation)(func(tx *gorm.DB, buf *message.Buffer) (Model, error) {
+			return p.WithTransaction(tx).Decline(buf)(juniorId, invitationId)()
+		})
+	}
+}
+
+func (p *ProcessorImpl) GetById(invitationId uint32) (Model, error) {
+	return model.Map(Make)(GetByIdProvider(p.t.Id(), invitationId)(p.db))()
+}
+
+func (p *ProcessorImpl) GetPendingByCharacterId(characterId uint32) ([]Model, error) {
+	return model.SliceMap(Make)(GetPendingByCharacterIdProvider(p.t.Id(), characterId)(p.db))(model.ParallelMap())()
+}
+
+// ExpireDue expires every pending invitation past its expiry, emitting within each invitation's tenant
+func ExpireDue(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) (int, error) {
+	due, err := GetDueProvider(time.Now())(db)()
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, e := range due {
+		t, err := tenant.Create(e.TenantId, e.Region, e.MajorVersion, e.MinorVersion)
+		if err != nil {
+			l.WithError(err).WithField("invitationId", e.ID).Error("Unable to restore tenant for invitation")
+			continue
+		}
+		tctx := tenant.WithContext(ctx, t)
+
+		err = outbox.Emit(l, tctx, db)(func(tx *gorm.DB, buf *message.Buffer) error {
+			_, err := NewProcessor(l, tctx, tx).Expire(buf)(e.ID)()
+			return err
+		})
+		if err != nil {
+			if !errors.Is(err, ErrInvitationNotPending) {
+				l.WithError(err).WithField("invitationId", e.ID).Error("Failed to expire invitation")
+			}
+			continue
+		}
+		expired++
+	}
+	return expired, nil
+}
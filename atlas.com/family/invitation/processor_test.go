@@ -0,0 +1,60 @@
+package invitation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"atlas-family/family"
+	"atlas-family/kafka/message"
+	"atlas-family/location"
+
+	tenant "github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestAccept_UsesCurrentLevels(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := errors.Join(Migration(db), family.Migration(db), family.FamilyMigration(db)); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+
+	tenantId := uuid.New()
+	tm, err := tenant.Create(tenantId, "GMS", 83, 1)
+	if err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+	p := NewProcessor(l, tenant.WithContext(context.Background(), tm), db)
+
+	previous := location.Default()
+	location.SetDefault(location.NewMemoryProvider().SetFallback(location.NewModel(1, 100000000)))
+	t.Cleanup(func() { location.SetDefault(previous) })
+
+	// The junior has outgrown the senior since the invitation was sent
+	for characterId, level := range map[uint32]uint16{1000: 50, 2000: 75} {
+		if err := db.Create(&family.Entity{CharacterId: characterId, TenantId: tenantId, Level: level, World: 1, CreatedAt: time.Now(), UpdatedAt: time.Now()}).Error; err != nil {
+			t.Fatalf("Failed to seed member %d: %v", characterId, err)
+		}
+	}
+	m, err := p.Create(nil)(1, 1000, 50, 2000, 35)()
+	if err != nil {
+		t.Fatalf("Failed to create invitation: %v", err)
+	}
+
+	if _, err := p.Accept(message.NewBuffer())(2000, m.Id())(); !errors.Is(err, family.ErrLevelDifferenceTooLarge) {
+		t.Fatalf("Expected the junior's current level to be validated, got %v", err)
+	}
+	if stored, err := p.GetById(m.Id()); err != nil || !stored.IsPending() {
+		t.Errorf("Expected the invitation to remain pending, got %v", err)
+	}
+}
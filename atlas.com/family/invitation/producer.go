@@ -0,0 +1,35 @@
+package invitation
+
+import (
+	"atlas-family/kafka/message/family"
+
+	"github.com/Chronicle20/atlas-kafka/producer"
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/segmentio/kafka-go"
+)
+
+// CreatedEventProvider creates a Kafka message provider notifying the junior of a new invitation
+func CreatedEventProvider(m Model) model.Provider[[]kafka.Message] {
+	return eventProvider(family.EventTypeInvitationCreated, m.JuniorId(), m)
+}
+
+// AcceptedEventProvider creates a Kafka message provider notifying the senior that the invitation was accepted
+func AcceptedEventProvider(m Model) model.Provider[[]kafka.Message] {
+	return eventProvider(family.EventTypeInvitationAccepted, m.SeniorId(), m)
+}
+
+// DeclinedEventProvider creates a Kafka message provider notifying the senior that the invitation was declined
+func DeclinedEventProvider(m Model) model.Provider[[]kafka.Message] {
+	return eventProvider(family.EventTypeInvitationDeclined, m.SeniorId(), m)
+}
+
+// ExpiredEventProvider creates a Kafka message provider notifying the senior that the invitation expired
+func ExpiredEventProvider(m Model) model.Provider[[]kafka.Message] {
+	return eventProvider(family.EventTypeInvitationExpired, m.SeniorId(), m)
+}
+
+func eventProvider(eventType string, characterId uint32, m Model) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(characterId))
+	value := family.NewInvitationEvent(eventType, m.WorldId(), characterId, m.Id(), m.SeniorId(), m.JuniorId(), m.ExpiresAt())
+	return producer.SingleMessageProvider(key, value)
+}
@@ -0,0 +1,61 @@
+package invitation
+
+import (
+	"atlas-family/database"
+	"errors"
+	"time"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetByIdProvider returns a provider for finding an invitation by ID within a tenant
+func GetByIdProvider(tenantId uuid.UUID, id uint32) database.EntityProvider[Entity] {
+	return func(db *gorm.DB) model.Provider[Entity] {
+		var entity Entity
+		if err := db.Where("tenant_id = ? AND id = ?", tenantId, id).First(&entity).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrorProvider[Entity](ErrInvitationNotFound)
+			}
+			return model.ErrorProvider[Entity](err)
+		}
+		return model.FixedProvider(entity)
+	}
+}
+
+// GetPendingByCharacterIdProvider returns a provider for the pending invitations a character sent or received
+func GetPendingByCharacterIdProvider(tenantId uuid.UUID, characterId uint32) database.EntityProvider[[]Entity] {
+	return func(db *gorm.DB) model.Provider[[]Entity] {
+		var entities []Entity
+		if err := db.Where("tenant_id = ? AND status = ? AND (senior_id = ? OR junior_id = ?)", tenantId, StatusPending, characterId, characterId).
+			Order("created_at").
+			Find(&entities).Error; err != nil {
+			return model.ErrorProvider[[]Entity](err)
+		}
+		return model.FixedProvider(entities)
+	}
+}
+
+// GetPendingByPairProvider returns a provider for the pending invitations between a senior and a junior
+func GetPendingByPairProvider(tenantId uuid.UUID, seniorId uint32, juniorId uint32) database.EntityProvider[[]Entity] {
+	return func(db *gorm.DB) model.Provider[[]Entity] {
+		var entities []Entity
+		if err := db.Where("tenant_id = ? AND status = ? AND senior_id = ? AND junior_id = ?", tenantId, StatusPending, seniorId, juniorId).
+			Find(&entities).Error; err != nil {
+			return model.ErrorProvider[[]Entity](err)
+		}
+		return model.FixedProvider(entities)
+	}
+}
+
+// GetDueProvider returns a provider for pending invitations across all tenants whose expiry has passed
+func GetDueProvider(now time.Time) database.EntityProvider[[]Entity] {
+	return func(db *gorm.DB) model.Provider[[]Entity] {
+		var entities []Entity
+		if err := db.Where("status = ? AND expires_at <= ?", StatusPending, now).Find(&entities).Error; err != nil {
+			return model.ErrorProvider[[]Entity](err)
+		}
+		return model.FixedProvider(entities)
+	}
+}
@@ -0,0 +1,155 @@
+package invitation
+
+import (
+	"atlas-family/database"
+	"atlas-family/family"
+	"atlas-family/rest"
+	"errors"
+	"net/http"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/Chronicle20/atlas-rest/server"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/jtumidanski/api2go/jsonapi"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// InitResource registers all invitation-related REST endpoints
+func InitResource(si jsonapi.ServerInformation) func(db *gorm.DB) server.RouteInitializer {
+	return func(db *gorm.DB) server.RouteInitializer {
+		return func(router *mux.Router, l logrus.FieldLogger) {
+			router.HandleFunc("/families/{characterId}/invitations", rest.RegisterInputHandler[CreateRequest](l)(si)("create_invitation", createInvitationHandler(db))).Methods(http.MethodPost)
+			router.HandleFunc("/families/{characterId}/invitations", rest.RegisterHandler(l)(si)("get_invitations", getInvitationsHandler(db))).Methods(http.MethodGet)
+			router.HandleFunc("/families/{characterId}/invitations/{invitationId}/accept", rest.RegisterHandler(l)(si)("accept_invitation", acceptInvitationHandler(db))).Methods(http.MethodPost)
+			router.HandleFunc("/families/{characterId}/invitations/{invitationId}/decline", rest.RegisterHandler(l)(si)("decline_invitation", declineInvitationHandler(db))).Methods(http.MethodPost)
+		}
+	}
+}
+
+// createInvitationHandler handles POST /families/{characterId}/invitations
+func createInvitationHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext, input CreateRequest) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext, input CreateRequest) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				if input.JuniorId == 0 {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, "Junior ID is required")
+					return
+				}
+
+				result, err := NewProcessor(d.Logger(), d.Context(), db).CreateAndEmit(uuid.New(), input.WorldId, characterId, input.SeniorLevel, input.JuniorId, input.JuniorLevel)()
+				if err != nil {
+					d.Logger().WithError(err).Error("Failed to create family invitation")
+					switch {
+					case errors.Is(err, ErrSelfInvitation):
+						rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+					case errors.Is(err, ErrInvitationAlreadyPending):
+						rest.WriteErrorResponse(w, http.StatusConflict, err.Error())
+					default:
+						rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+					}
+					return
+				}
+
+				writeInvitation(d, c, w, r, result)
+			}
+		})
+	}
+}
+
+// getInvitationsHandler handles GET /families/{characterId}/invitations
+func getInvitationsHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				invitations, err := NewProcessor(d.Logger(), d.Context(), db).GetPendingByCharacterId(characterId)
+				if err != nil {
+					d.Logger().WithError(err).Error("Failed to get family invitations")
+					rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+					return
+				}
+
+				rms, err := model.SliceMap(Transform)(model.FixedProvider(invitations))(model.ParallelMap())()
+				if err != nil {
+					d.Logger().WithError(err).Error("Failed to transform family invitations to REST model")
+					rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+					return
+				}
+
+				query := r.URL.Query()
+				queryParams := jsonapi.ParseQueryFields(&query)
+				server.MarshalResponse[[]RestModel](d.Logger())(w)(c.ServerInformation())(queryParams)(rms)
+			}
+		})
+	}
+}
+
+// acceptInvitationHandler handles POST /families/{characterId}/invitations/{invitationId}/accept
+func acceptInvitationHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return rest.ParseInvitationId(d.Logger(), func(invitationId uint32) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					result, err := NewProcessor(d.Logger(), d.Context(), db).AcceptAndEmit(uuid.New(), characterId, invitationId)()
+					if err != nil {
+						d.Logger().WithError(err).Error("Failed to accept family invitation")
+						writeResponseError(w, err)
+						return
+					}
+
+					writeInvitation(d, c, w, r, result)
+				}
+			})
+		})
+	}
+}
+
+// declineInvitationHandler handles POST /families/{characterId}/invitations/{invitationId}/decline
+func declineInvitationHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return rest.ParseInvitationId(d.Logger(), func(invitationId uint32) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					result, err := NewProcessor(d.Logger(), d.Context(), db).DeclineAndEmit(uuid.New(), characterId, invitationId)()
+					if err != nil {
+						d.Logger().WithError(err).Error("Failed to decline family invitation")
+						writeResponseError(w, err)
+						return
+					}
+
+					writeInvitation(d, c, w, r, result)
+				}
+			})
+		})
+	}
+}
+
+// writeResponseError maps invitation and linking errors to HTTP status codes
+func writeResponseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrInvitationNotFound), errors.Is(err, family.ErrSeniorNotFound), errors.Is(err, family.ErrJuniorNotFound), errors.Is(err, family.ErrMemberNotFound):
+		rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrInvitationNotPending), errors.Is(err, family.ErrSeniorHasTooManyJuniors), errors.Is(err, family.ErrJuniorAlreadyLinked), errors.Is(err, family.ErrCooldownActive), errors.Is(err, family.ErrLevelDifferenceTooLarge), errors.Is(err, family.ErrNotSameWorld), errors.Is(err, family.ErrNotOnSameMap), errors.Is(err, family.ErrCycleDetected), errors.Is(err, database.ErrConflict):
+		rest.WriteErrorResponse(w, http.StatusConflict, err.Error())
+	case errors.Is(err, ErrInvitationExpired):
+		rest.WriteErrorResponse(w, http.StatusGone, err.Error())
+	case errors.Is(err, family.ErrLocationUnavailable):
+		rest.WriteErrorResponse(w, http.StatusServiceUnavailable, err.Error())
+	default:
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+func writeInvitation(d *rest.HandlerDependency, c *rest.HandlerContext, w http.ResponseWriter, r *http.Request, m Model) {
+	rm, err := Transform(m)
+	if err != nil {
+		d.Logger().WithError(err).Error("Failed to transform family invitation to REST model")
+		rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	query := r.URL.Query()
+	queryParams := jsonapi.ParseQueryFields(&query)
+	server.MarshalResponse[RestModel](d.Logger())(w)(c.ServerInformation())(queryParams)(rm)
+}
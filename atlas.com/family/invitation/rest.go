@@ -0,0 +1,69 @@
+package invitation
+
+import (
+	"strconv"
+	"time"
+)
+
+// RestModel represents an invitation in REST/JSON:API format
+type RestModel struct {
+	Id          uint32 `json:"-"`
+	WorldId     byte   `json:"worldId"`
+	SeniorId    uint32 `json:"seniorId"`
+	SeniorLevel uint16 `json:"seniorLevel"`
+	JuniorId    uint32 `json:"juniorId"`
+	JuniorLevel uint16 `json:"juniorLevel"`
+	Status      string `json:"status"`
+	ExpiresAt   string `json:"expiresAt"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
+// GetName returns the resource type for JSON:API compatibility
+func (r RestModel) GetName() string {
+	return "familyInvitations"
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r RestModel) GetID() string {
+	return strconv.FormatUint(uint64(r.Id), 10)
+}
+
+// Transform converts a domain invitation to REST representation
+func Transform(m Model) (RestModel, error) {
+	return RestModel{
+		Id:          m.Id(),
+		WorldId:     m.WorldId(),
+		SeniorId:    m.SeniorId(),
+		SeniorLevel: m.SeniorLevel(),
+		JuniorId:    m.JuniorId(),
+		JuniorLevel: m.JuniorLevel(),
+		Status:      m.Status(),
+		ExpiresAt:   m.ExpiresAt().Format(time.RFC3339),
+		CreatedAt:   m.CreatedAt().Format(time.RFC3339),
+		UpdatedAt:   m.UpdatedAt().Format(time.RFC3339),
+	}, nil
+}
+
+// CreateRequest represents the request body for inviting a prospective junior
+type CreateRequest struct {
+	WorldId     byte   `json:"worldId"`
+	SeniorLevel uint16 `json:"seniorLevel"`
+	JuniorId    uint32 `json:"juniorId"`
+	JuniorLevel uint16 `json:"juniorLevel"`
+}
+
+// GetName returns the resource type for JSON:API compatibility
+func (r CreateRequest) GetName() string {
+	return "familyInvitations"
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r CreateRequest) GetID() string {
+	return ""
+}
+
+// SetID accepts the client supplied ID, which is ignored for new invitations
+func (r *CreateRequest) SetID(_ string) error {
+	return nil
+}
@@ -0,0 +1,106 @@
+package character
+
+import (
+	"atlas-family/family"
+	"atlas-family/idempotency"
+	consumer2 "atlas-family/kafka/consumer"
+	charactermsg "atlas-family/kafka/message/character"
+	"context"
+	"errors"
+
+	"github.com/Chronicle20/atlas-kafka/consumer"
+	"github.com/Chronicle20/atlas-kafka/handler"
+	"github.com/Chronicle20/atlas-kafka/message"
+	"github.com/Chronicle20/atlas-kafka/topic"
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func InitConsumers(l logrus.FieldLogger) func(func(config consumer.Config, decorators ...model.Decorator[consumer.Config])) func(consumerGroupId string) {
+	return func(rf func(config consumer.Config, decorators ...model.Decorator[consumer.Config])) func(consumerGroupId string) {
+		return func(consumerGroupId string) {
+			rf(consumer2.NewConfig(l)("character_status_event")(charactermsg.EnvEventTopicCharacterStatus)(consumerGroupId), consumer.SetHeaderParsers(consumer.SpanHeaderParser, consumer.TenantHeaderParser))
+		}
+	}
+}
+
+func InitHandlers(l logrus.FieldLogger) func(db *gorm.DB) func(rf func(topic string, handler handler.Handler) (string, error)) {
+	return func(db *gorm.DB) func(rf func(topic string, handler handler.Handler) (string, error)) {
+		return func(rf func(topic string, handler handler.Handler) (string, error)) {
+			var t string
+			t, _ = topic.EnvProvider(l)(charactermsg.EnvEventTopicCharacterStatus)()
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(handleLevelChangedStatusEvent(db))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(handleChannelChangedStatusEvent(db))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(handleDeletedStatusEvent(db))))
+		}
+	}
+}
+
+// handleLevelChangedStatusEvent records a character's new level on their family member
+func handleLevelChangedStatusEvent(db *gorm.DB) func(logrus.FieldLogger, context.Context, charactermsg.StatusEvent[charactermsg.LevelChangedStatusEventBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, e charactermsg.StatusEvent[charactermsg.LevelChangedStatusEventBody]) {
+		if e.Type != charactermsg.StatusEventTypeLevelChanged {
+			return
+		}
+
+		l.WithFields(logrus.Fields{
+			"transactionId": e.TransactionId,
+			"characterId":   e.CharacterId,
+			"level":         e.Body.Current,
+		}).Debug("Processing character level changed event")
+
+		_, err := family.NewProcessor(l, ctx, db).UpdateLevelAndEmit(e.TransactionId, e.CharacterId, e.Body.Current)()
+		logResult(l, err, "level changed")
+	}
+}
+
+// handleChannelChangedStatusEvent records the world a character moved to on their family member
+func handleChannelChangedStatusEvent(db *gorm.DB) func(logrus.FieldLogger, context.Context, charactermsg.StatusEvent[charactermsg.ChannelChangedStatusEventBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, e charactermsg.StatusEvent[charactermsg.ChannelChangedStatusEventBody]) {
+		if e.Type != charactermsg.StatusEventTypeChannelChanged {
+			return
+		}
+
+		l.WithFields(logrus.Fields{
+			"transactionId": e.TransactionId,
+			"characterId":   e.CharacterId,
+			"worldId":       e.WorldId,
+		}).Debug("Processing character channel changed event")
+
+		_, err := family.NewProcessor(l, ctx, db).ChangeWorldAndEmit(e.TransactionId, e.CharacterId, e.WorldId)()
+		logResult(l, err, "channel changed")
+	}
+}
+
+// handleDeletedStatusEvent removes a deleted character from their family and tombstones them
+func handleDeletedStatusEvent(db *gorm.DB) func(logrus.FieldLogger, context.Context, charactermsg.StatusEvent[charactermsg.DeletedStatusEventBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, e charactermsg.StatusEvent[charactermsg.DeletedStatusEventBody]) {
+		if e.Type != charactermsg.StatusEventTypeDeleted {
+			return
+		}
+
+		l.WithFields(logrus.Fields{
+			"transactionId": e.TransactionId,
+			"characterId":   e.CharacterId,
+		}).Info("Processing character deleted event")
+
+		_, err := family.NewProcessor(l, ctx, db).DeleteCharacterAndEmit(e.TransactionId, e.CharacterId)()
+		logResult(l, err, "deleted")
+	}
+}
+
+// logResult reports the outcome of applying a status event. Characters without a family member are not tracked, so
+// their events are skipped.
+func logResult(l logrus.FieldLogger, err error, event string) {
+	switch {
+	case errors.Is(err, family.ErrMemberNotFound):
+		l.Debugf("Skipping character %s event for non-member", event)
+	case errors.Is(err, idempotency.ErrDuplicateCommand):
+		l.Infof("Acknowledged duplicate character %s event", event)
+	case err != nil:
+		l.WithError(err).Errorf("Failed to process character %s event", event)
+	default:
+		l.Debugf("Successfully processed character %s event", event)
+	}
+}
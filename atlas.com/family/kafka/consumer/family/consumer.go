@@ -1,10 +1,16 @@
 package family
 
 import (
+	"atlas-family/buff"
 	"atlas-family/family"
+	"atlas-family/idempotency"
+	"atlas-family/invitation"
 	consumer2 "atlas-family/kafka/consumer"
 	familymsg "atlas-family/kafka/message/family"
+	"atlas-family/location"
+	"atlas-family/teleport"
 	"context"
+	"errors"
 
 	"github.com/Chronicle20/atlas-kafka/consumer"
 	"github.com/Chronicle20/atlas-kafka/handler"
@@ -28,15 +34,88 @@ func InitHandlers(l logrus.FieldLogger) func(db *gorm.DB) func(rf func(topic str
 		return func(rf func(topic string, handler handler.Handler) (string, error)) {
 			var t string
 			t, _ = topic.EnvProvider(l)(familymsg.EnvCommandTopic)()
-			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(handleAddJuniorCommand(db))))
-			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(handleRemoveMemberCommand(db))))
-			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(handleBreakLinkCommand(db))))
-			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(handleAwardRepCommand(db))))
-			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(handleDeductRepCommand(db))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeCreateMember, handleCreateMemberCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeAddJunior, handleAddJuniorCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeRemoveMember, handleRemoveMemberCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeBreakLink, handleBreakLinkCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeLeaveSenior, handleLeaveSeniorCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeExpelJunior, handleExpelJuniorCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeClearCooldown, handleClearCooldownCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeDissolveFamily, handleDissolveFamilyCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeAwardRep, handleAwardRepCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeDeductRep, handleDeductRepCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeRegisterKillActivity, handleRegisterKillActivityCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeRegisterExpeditionActivity, handleRegisterExpeditionActivityCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeInviteJunior, handleInviteJuniorCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeAcceptInvitation, handleAcceptInvitationCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeDeclineInvitation, handleDeclineInvitationCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeRedeemBuff, handleRedeemBuffCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeTeleportToMember, handleTeleportToMemberCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeSummonMember, handleSummonMemberCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeRenameFamily, handleRenameFamilyCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeSetPrecept, handleSetPreceptCommand(db)))))
+			_, _ = rf(t, message.AdaptHandler(message.PersistentConfig(rejectDeleted(db, familymsg.CommandTypeSetNotice, handleSetNoticeCommand(db)))))
 		}
 	}
 }
 
+// rejectDeleted guards the handler of a command type, rejecting commands issued by a deleted character with a
+// CHARACTER_DELETED error event instead of applying them
+func rejectDeleted[E any](db *gorm.DB, commandType string, h func(logrus.FieldLogger, context.Context, familymsg.Command[E])) func(logrus.FieldLogger, context.Context, familymsg.Command[E]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[E]) {
+		if cmd.Type != commandType {
+			h(l, ctx, cmd)
+			return
+		}
+
+		err := family.NewProcessor(l, ctx, db).RejectDeletedAndEmit(cmd.WorldId, cmd.CharacterId)
+		if errors.Is(err, family.ErrCharacterDeleted) {
+			l.WithFields(logrus.Fields{
+				"transactionId": cmd.TransactionId,
+				"characterId":   cmd.CharacterId,
+				"type":          cmd.Type,
+			}).Warn("Rejecting command for deleted character")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to check whether character was deleted")
+			return
+		}
+		h(l, ctx, cmd)
+	}
+}
+
+// handleCreateMemberCommand handles create member commands
+func handleCreateMemberCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.CreateMemberCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.CreateMemberCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"level":         cmd.Body.Level,
+			"type":          cmd.Type,
+		}).Info("Processing create member command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeCreateMember {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-create-member command")
+			return
+		}
+
+		// Process the create member operation
+		_, err := family.NewProcessor(l, ctx, db).CreateMemberAndEmit(cmd.TransactionId, cmd.WorldId, cmd.CharacterId, cmd.Body.Level)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate create member command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process create member command")
+			return
+		}
+
+		l.Info("Successfully processed create member command")
+	}
+}
+
 // handleAddJuniorCommand handles add junior commands
 func handleAddJuniorCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.AddJuniorCommandBody]) {
 	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.AddJuniorCommandBody]) {
@@ -54,22 +133,22 @@ func handleAddJuniorCommand(db *gorm.DB) func(logrus.FieldLogger, context.Contex
 		}
 
 		fp := family.NewProcessor(l, ctx, db)
-
-		// Ensure both senior and junior exist as family members
-		_, err := fp.GetByCharacterId(cmd.CharacterId)
-		if err != nil {
-			l.WithError(err).Error("Failed to ensure senior member exists")
-			return
+		if cmd.Body.AutoEnroll {
+			fp = fp.WithAutoEnroll()
 		}
-
-		_, err = fp.GetByCharacterId(cmd.Body.JuniorId)
-		if err != nil {
-			l.WithError(err).Error("Failed to ensure junior member exists")
-			return
+		if cmd.Body.SeniorMapId != nil {
+			fp = fp.WithLocation(cmd.CharacterId, location.NewModel(cmd.WorldId, *cmd.Body.SeniorMapId))
+		}
+		if cmd.Body.JuniorMapId != nil {
+			fp = fp.WithLocation(cmd.Body.JuniorId, location.NewModel(cmd.WorldId, *cmd.Body.JuniorMapId))
 		}
 
 		// Process the add junior operation
-		_, err = fp.AddJuniorAndEmit(cmd.TransactionId, cmd.WorldId, cmd.CharacterId, cmd.Body.SeniorLevel, cmd.Body.JuniorId, cmd.Body.JuniorLevel)()
+		_, err := fp.AddJuniorAndEmit(cmd.TransactionId, cmd.WorldId, cmd.CharacterId, cmd.Body.SeniorLevel, cmd.Body.JuniorId, cmd.Body.JuniorLevel)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate add junior command")
+			return
+		}
 		if err != nil {
 			l.WithError(err).Error("Failed to process add junior command")
 			return
@@ -98,6 +177,10 @@ func handleRemoveMemberCommand(db *gorm.DB) func(logrus.FieldLogger, context.Con
 
 		// Process the remove member operation
 		_, err := family.NewProcessor(l, ctx, db).RemoveMemberAndEmit(cmd.TransactionId, cmd.Body.TargetId, cmd.Body.Reason)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate remove member command")
+			return
+		}
 		if err != nil {
 			l.WithError(err).Error("Failed to process remove member command")
 			return
@@ -113,7 +196,6 @@ func handleBreakLinkCommand(db *gorm.DB) func(logrus.FieldLogger, context.Contex
 		l.WithFields(logrus.Fields{
 			"transactionId": cmd.TransactionId,
 			"characterId":   cmd.CharacterId,
-			"reason":        cmd.Body.Reason,
 			"type":          cmd.Type,
 		}).Info("Processing break link command")
 
@@ -124,7 +206,11 @@ func handleBreakLinkCommand(db *gorm.DB) func(logrus.FieldLogger, context.Contex
 		}
 
 		// Process the break link operation
-		_, err := family.NewProcessor(l, ctx, db).BreakLinkAndEmit(cmd.TransactionId, cmd.CharacterId, cmd.Body.Reason)()
+		_, err := family.NewProcessor(l, ctx, db).BreakLinkAndEmit(cmd.TransactionId, cmd.CharacterId)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate break link command")
+			return
+		}
 		if err != nil {
 			l.WithError(err).Error("Failed to process break link command")
 			return
@@ -134,6 +220,127 @@ func handleBreakLinkCommand(db *gorm.DB) func(logrus.FieldLogger, context.Contex
 	}
 }
 
+// handleLeaveSeniorCommand handles leave senior commands
+func handleLeaveSeniorCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.LeaveSeniorCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.LeaveSeniorCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"type":          cmd.Type,
+		}).Info("Processing leave senior command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeLeaveSenior {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-leave-senior command")
+			return
+		}
+
+		// Process the leave senior operation
+		_, err := family.NewProcessor(l, ctx, db).LeaveSeniorAndEmit(cmd.TransactionId, cmd.CharacterId)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate leave senior command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process leave senior command")
+			return
+		}
+
+		l.Info("Successfully processed leave senior command")
+	}
+}
+
+// handleExpelJuniorCommand handles expel junior commands
+func handleExpelJuniorCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.ExpelJuniorCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.ExpelJuniorCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"juniorId":      cmd.Body.JuniorId,
+			"type":          cmd.Type,
+		}).Info("Processing expel junior command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeExpelJunior {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-expel-junior command")
+			return
+		}
+
+		// Process the expel junior operation
+		_, err := family.NewProcessor(l, ctx, db).ExpelJuniorAndEmit(cmd.TransactionId, cmd.CharacterId, cmd.Body.JuniorId)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate expel junior command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process expel junior command")
+			return
+		}
+
+		l.Info("Successfully processed expel junior command")
+	}
+}
+
+// handleClearCooldownCommand handles clear cooldown commands
+func handleClearCooldownCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.ClearCooldownCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.ClearCooldownCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"type":          cmd.Type,
+		}).Info("Processing clear cooldown command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeClearCooldown {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-clear-cooldown command")
+			return
+		}
+
+		// Process the clear cooldown operation
+		_, err := family.NewProcessor(l, ctx, db).ClearCooldownAndEmit(cmd.TransactionId, cmd.CharacterId)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate clear cooldown command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process clear cooldown command")
+			return
+		}
+
+		l.Info("Successfully processed clear cooldown command")
+	}
+}
+
+// handleDissolveFamilyCommand handles dissolve family commands
+func handleDissolveFamilyCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.DissolveFamilyCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.DissolveFamilyCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"type":          cmd.Type,
+		}).Info("Processing dissolve family command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeDissolveFamily {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-dissolve-family command")
+			return
+		}
+
+		// Process the dissolve family operation
+		_, err := family.NewProcessor(l, ctx, db).DissolveFamilyAndEmit(cmd.TransactionId, cmd.CharacterId)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate dissolve family command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process dissolve family command")
+			return
+		}
+
+		l.Info("Successfully processed dissolve family command")
+	}
+}
+
 // handleAwardRepCommand handles award reputation commands
 func handleAwardRepCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.AwardRepCommandBody]) {
 	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.AwardRepCommandBody]) {
@@ -151,8 +358,17 @@ func handleAwardRepCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context
 			return
 		}
 
-		// Process the deduct reputation operation
-		_, err := family.NewProcessor(l, ctx, db).AwardRepAndEmit(cmd.TransactionId, cmd.CharacterId, cmd.Body.Amount, cmd.Body.Source)()
+		// Process the award reputation operation, crediting the seniors when propagation is requested
+		var err error
+		if cmd.Body.Propagate {
+			_, err = family.NewProcessor(l, ctx, db).PropagateRepAndEmit(cmd.TransactionId, cmd.CharacterId, cmd.Body.Amount, cmd.Body.Source)()
+		} else {
+			_, err = family.NewProcessor(l, ctx, db).AwardRepAndEmit(cmd.TransactionId, cmd.CharacterId, cmd.Body.Amount, cmd.Body.Source)()
+		}
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate award reputation command")
+			return
+		}
 		if err != nil {
 			l.WithError(err).Error("Failed to process award reputation command")
 			return
@@ -181,6 +397,10 @@ func handleDeductRepCommand(db *gorm.DB) func(logrus.FieldLogger, context.Contex
 
 		// Process the deduct reputation operation
 		_, err := family.NewProcessor(l, ctx, db).DeductRepAndEmit(cmd.TransactionId, cmd.CharacterId, cmd.Body.Amount, cmd.Body.Reason)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate deduct reputation command")
+			return
+		}
 		if err != nil {
 			l.WithError(err).Error("Failed to process deduct reputation command")
 			return
@@ -189,3 +409,347 @@ func handleDeductRepCommand(db *gorm.DB) func(logrus.FieldLogger, context.Contex
 		l.Info("Successfully processed deduct reputation command")
 	}
 }
+
+// handleRegisterKillActivityCommand handles register kill activity commands
+func handleRegisterKillActivityCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.RegisterKillActivityCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.RegisterKillActivityCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"killCount":     cmd.Body.KillCount,
+			"type":          cmd.Type,
+		}).Info("Processing register kill activity command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeRegisterKillActivity {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-register-kill-activity command")
+			return
+		}
+
+		// Process the kill activity
+		_, err := family.NewProcessor(l, ctx, db).RegisterActivityAndEmit(cmd.TransactionId, cmd.CharacterId, family.ActivityTypeMobKill, cmd.Body.KillCount)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate register kill activity command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process register kill activity command")
+			return
+		}
+
+		l.Info("Successfully processed register kill activity command")
+	}
+}
+
+// handleRegisterExpeditionActivityCommand handles register expedition activity commands
+func handleRegisterExpeditionActivityCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.RegisterExpeditionActivityCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.RegisterExpeditionActivityCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"coinReward":    cmd.Body.CoinReward,
+			"type":          cmd.Type,
+		}).Info("Processing register expedition activity command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeRegisterExpeditionActivity {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-register-expedition-activity command")
+			return
+		}
+
+		// Process the expedition activity
+		_, err := family.NewProcessor(l, ctx, db).RegisterActivityAndEmit(cmd.TransactionId, cmd.CharacterId, family.ActivityTypeExpedition, cmd.Body.CoinReward)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate register expedition activity command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process register expedition activity command")
+			return
+		}
+
+		l.Info("Successfully processed register expedition activity command")
+	}
+}
+
+// handleInviteJuniorCommand handles invite junior commands
+func handleInviteJuniorCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.InviteJuniorCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.InviteJuniorCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"juniorId":      cmd.Body.JuniorId,
+			"type":          cmd.Type,
+		}).Info("Processing invite junior command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeInviteJunior {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-invite-junior command")
+			return
+		}
+
+		// Process the invitation
+		_, err := invitation.NewProcessor(l, ctx, db).CreateAndEmit(cmd.TransactionId, cmd.WorldId, cmd.CharacterId, cmd.Body.SeniorLevel, cmd.Body.JuniorId, cmd.Body.JuniorLevel)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate invite junior command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process invite junior command")
+			return
+		}
+
+		l.Info("Successfully processed invite junior command")
+	}
+}
+
+// handleAcceptInvitationCommand handles accept invitation commands
+func handleAcceptInvitationCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.AcceptInvitationCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.AcceptInvitationCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"invitationId":  cmd.Body.InvitationId,
+			"type":          cmd.Type,
+		}).Info("Processing accept invitation command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeAcceptInvitation {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-accept-invitation command")
+			return
+		}
+
+		// Process the acceptance
+		_, err := invitation.NewProcessor(l, ctx, db).AcceptAndEmit(cmd.TransactionId, cmd.CharacterId, cmd.Body.InvitationId)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate accept invitation command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process accept invitation command")
+			return
+		}
+
+		l.Info("Successfully processed accept invitation command")
+	}
+}
+
+// handleDeclineInvitationCommand handles decline invitation commands
+func handleDeclineInvitationCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.DeclineInvitationCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.DeclineInvitationCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"invitationId":  cmd.Body.InvitationId,
+			"type":          cmd.Type,
+		}).Info("Processing decline invitation command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeDeclineInvitation {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-decline-invitation command")
+			return
+		}
+
+		// Process the decline
+		_, err := invitation.NewProcessor(l, ctx, db).DeclineAndEmit(cmd.TransactionId, cmd.CharacterId, cmd.Body.InvitationId)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate decline invitation command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process decline invitation command")
+			return
+		}
+
+		l.Info("Successfully processed decline invitation command")
+	}
+}
+
+// handleRedeemBuffCommand handles redeem buff commands
+func handleRedeemBuffCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.RedeemBuffCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.RedeemBuffCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"buffType":      cmd.Body.BuffType,
+			"type":          cmd.Type,
+		}).Info("Processing redeem buff command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeRedeemBuff {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-redeem-buff command")
+			return
+		}
+
+		// Process the redemption
+		_, err := buff.NewProcessor(l, ctx, db).RedeemAndEmit(cmd.TransactionId, cmd.CharacterId, cmd.Body.BuffType)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate redeem buff command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process redeem buff command")
+			return
+		}
+
+		l.Info("Successfully processed redeem buff command")
+	}
+}
+
+// handleTeleportToMemberCommand handles teleport to member commands
+func handleTeleportToMemberCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.TeleportCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.TeleportCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"targetId":      cmd.Body.TargetId,
+			"mapId":         cmd.Body.MapId,
+			"type":          cmd.Type,
+		}).Info("Processing teleport to member command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeTeleportToMember {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-teleport-to-member command")
+			return
+		}
+
+		// Process the warp
+		_, err := teleport.NewProcessor(l, ctx, db).TeleportToMemberAndEmit(cmd.TransactionId, cmd.WorldId, cmd.CharacterId, cmd.Body.TargetId, cmd.Body.MapId)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate teleport to member command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process teleport to member command")
+			return
+		}
+
+		l.Info("Successfully processed teleport to member command")
+	}
+}
+
+// handleSummonMemberCommand handles summon member commands
+func handleSummonMemberCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.TeleportCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.TeleportCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"targetId":      cmd.Body.TargetId,
+			"mapId":         cmd.Body.MapId,
+			"type":          cmd.Type,
+		}).Info("Processing summon member command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeSummonMember {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-summon-member command")
+			return
+		}
+
+		// Process the warp
+		_, err := teleport.NewProcessor(l, ctx, db).SummonMemberAndEmit(cmd.TransactionId, cmd.WorldId, cmd.CharacterId, cmd.Body.TargetId, cmd.Body.MapId)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate summon member command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process summon member command")
+			return
+		}
+
+		l.Info("Successfully processed summon member command")
+	}
+}
+
+// handleRenameFamilyCommand handles rename family commands
+func handleRenameFamilyCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.RenameFamilyCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.RenameFamilyCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"familyId":      cmd.Body.FamilyId,
+			"name":          cmd.Body.Name,
+			"type":          cmd.Type,
+		}).Info("Processing rename family command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeRenameFamily {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-rename-family command")
+			return
+		}
+
+		// Process the rename
+		_, err := family.NewProcessor(l, ctx, db).RenameFamilyAndEmit(cmd.TransactionId, cmd.Body.FamilyId, cmd.CharacterId, cmd.Body.Name)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate rename family command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process rename family command")
+			return
+		}
+
+		l.Info("Successfully processed rename family command")
+	}
+}
+
+// handleSetPreceptCommand handles set precept commands
+func handleSetPreceptCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.SetFamilyTextCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.SetFamilyTextCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"familyId":      cmd.Body.FamilyId,
+			"type":          cmd.Type,
+		}).Info("Processing set precept command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeSetPrecept {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-set-precept command")
+			return
+		}
+
+		// Process the update
+		_, err := family.NewProcessor(l, ctx, db).SetPreceptAndEmit(cmd.TransactionId, cmd.Body.FamilyId, cmd.CharacterId, cmd.Body.Text)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate set precept command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process set precept command")
+			return
+		}
+
+		l.Info("Successfully processed set precept command")
+	}
+}
+
+// handleSetNoticeCommand handles set notice commands
+func handleSetNoticeCommand(db *gorm.DB) func(logrus.FieldLogger, context.Context, familymsg.Command[familymsg.SetFamilyTextCommandBody]) {
+	return func(l logrus.FieldLogger, ctx context.Context, cmd familymsg.Command[familymsg.SetFamilyTextCommandBody]) {
+		l.WithFields(logrus.Fields{
+			"transactionId": cmd.TransactionId,
+			"characterId":   cmd.CharacterId,
+			"familyId":      cmd.Body.FamilyId,
+			"type":          cmd.Type,
+		}).Info("Processing set notice command")
+
+		// Validate command type
+		if cmd.Type != familymsg.CommandTypeSetNotice {
+			l.WithField("type", cmd.Type).Warn("Ignoring non-set-notice command")
+			return
+		}
+
+		// Process the update
+		_, err := family.NewProcessor(l, ctx, db).SetNoticeAndEmit(cmd.TransactionId, cmd.Body.FamilyId, cmd.CharacterId, cmd.Body.Text)()
+		if errors.Is(err, idempotency.ErrDuplicateCommand) {
+			l.Info("Acknowledged duplicate set notice command")
+			return
+		}
+		if err != nil {
+			l.WithError(err).Error("Failed to process set notice command")
+			return
+		}
+
+		l.Info("Successfully processed set notice command")
+	}
+}
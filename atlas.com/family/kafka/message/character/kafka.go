@@ -0,0 +1,44 @@
+package character
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	EnvEventTopicCharacterStatus = "EVENT_TOPIC_CHARACTER_STATUS"
+)
+
+// Status Event Type Constants
+const (
+	StatusEventTypeLevelChanged   = "LEVEL_CHANGED"
+	StatusEventTypeChannelChanged = "CHANNEL_CHANGED"
+	StatusEventTypeDeleted        = "DELETED"
+)
+
+// StatusEvent represents a generic character status event published by the character service
+type StatusEvent[E any] struct {
+	TransactionId uuid.UUID `json:"transactionId"`
+	WorldId       byte      `json:"worldId"`
+	CharacterId   uint32    `json:"characterId"`
+	Type          string    `json:"type"`
+	Body          E         `json:"body"`
+}
+
+// LevelChangedStatusEventBody represents the body of a character level change
+type LevelChangedStatusEventBody struct {
+	ChannelId byte   `json:"channelId"`
+	Amount    byte   `json:"amount"`
+	Current   uint16 `json:"current"`
+}
+
+// ChannelChangedStatusEventBody represents the body of a character changing world or channel. The new world is
+// carried by the event.
+type ChannelChangedStatusEventBody struct {
+	ChannelId    byte   `json:"channelId"`
+	OldChannelId byte   `json:"oldChannelId"`
+	MapId        uint32 `json:"mapId"`
+}
+
+// DeletedStatusEventBody represents the body of a character deletion
+type DeletedStatusEventBody struct {
+}
@@ -27,9 +27,17 @@ type Event[E any] struct {
 
 // AddJuniorCommandBody represents the body for adding a junior to a family
 type AddJuniorCommandBody struct {
-	JuniorId    uint32 `json:"juniorId"`
-	SeniorLevel uint16 `json:"seniorLevel"`
-	JuniorLevel uint16 `json:"juniorLevel"`
+	JuniorId    uint32  `json:"juniorId"`
+	SeniorLevel uint16  `json:"seniorLevel"`
+	JuniorLevel uint16  `json:"juniorLevel"`
+	AutoEnroll  bool    `json:"autoEnroll,omitempty"`
+	SeniorMapId *uint32 `json:"seniorMapId,omitempty"`
+	JuniorMapId *uint32 `json:"juniorMapId,omitempty"`
+}
+
+// CreateMemberCommandBody represents the body for enrolling a character as a family member
+type CreateMemberCommandBody struct {
+	Level uint16 `json:"level"`
 }
 
 // RemoveMemberCommandBody represents the body for removing a member from a family
@@ -40,7 +48,23 @@ type RemoveMemberCommandBody struct {
 
 // BreakLinkCommandBody represents the body for breaking a family link
 type BreakLinkCommandBody struct {
-	Reason string `json:"reason,omitempty"`
+}
+
+// LeaveSeniorCommandBody represents the body for a junior leaving their senior
+type LeaveSeniorCommandBody struct {
+}
+
+// ExpelJuniorCommandBody represents the body for a senior expelling one of their juniors
+type ExpelJuniorCommandBody struct {
+	JuniorId uint32 `json:"juniorId"`
+}
+
+// ClearCooldownCommandBody represents the body for clearing a member's cooldown
+type ClearCooldownCommandBody struct {
+}
+
+// DissolveFamilyCommandBody represents the body for a leader dissolving their family
+type DissolveFamilyCommandBody struct {
 }
 
 // DeductRepCommandBody represents the body for deducting reputation
@@ -53,6 +77,7 @@ type DeductRepCommandBody struct {
 type AwardRepCommandBody struct {
 	Amount    uint32    `json:"amount"`
 	Source    string    `json:"source"`
+	Propagate bool      `json:"propagate,omitempty"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
@@ -68,6 +93,23 @@ type RegisterExpeditionActivityCommandBody struct {
 	Timestamp  time.Time `json:"timestamp"`
 }
 
+// InviteJuniorCommandBody represents the body for inviting a prospective junior
+type InviteJuniorCommandBody struct {
+	JuniorId    uint32 `json:"juniorId"`
+	SeniorLevel uint16 `json:"seniorLevel"`
+	JuniorLevel uint16 `json:"juniorLevel"`
+}
+
+// AcceptInvitationCommandBody represents the body for a junior accepting an invitation
+type AcceptInvitationCommandBody struct {
+	InvitationId uint32 `json:"invitationId"`
+}
+
+// DeclineInvitationCommandBody represents the body for a junior declining an invitation
+type DeclineInvitationCommandBody struct {
+	InvitationId uint32 `json:"invitationId"`
+}
+
 // Event Body Types
 
 // LinkCreatedEventBody represents the body for link created events
@@ -79,10 +121,12 @@ type LinkCreatedEventBody struct {
 
 // LinkBrokenEventBody represents the body for link broken events
 type LinkBrokenEventBody struct {
-	SeniorId  uint32    `json:"seniorId"`
-	JuniorId  uint32    `json:"juniorId"`
-	Reason    string    `json:"reason"`
-	Timestamp time.Time `json:"timestamp"`
+	SeniorId      uint32     `json:"seniorId"`
+	JuniorId      uint32     `json:"juniorId"`
+	Reason        string     `json:"reason"`
+	RepCost       uint32     `json:"repCost,omitempty"`
+	CooldownUntil *time.Time `json:"cooldownUntil,omitempty"`
+	Timestamp     time.Time  `json:"timestamp"`
 }
 
 // TreeDissolvedEventBody represents the body for tree dissolved events
@@ -95,10 +139,11 @@ type TreeDissolvedEventBody struct {
 
 // RepGainedEventBody represents the body for reputation gained events
 type RepGainedEventBody struct {
-	RepGained uint32    `json:"repGained"`
-	DailyRep  uint32    `json:"dailyRep"`
-	Source    string    `json:"source"`
-	Timestamp time.Time `json:"timestamp"`
+	RepGained         uint32    `json:"repGained"`
+	DailyRep          uint32    `json:"dailyRep"`
+	Source            string    `json:"source"`
+	SourceCharacterId uint32    `json:"sourceCharacterId,omitempty"`
+	Timestamp         time.Time `json:"timestamp"`
 }
 
 // RepRedeemedEventBody represents the body for reputation redeemed events
@@ -146,6 +191,75 @@ type TeleportUsedEventBody struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// RedeemBuffCommandBody represents the body for redeeming a family buff
+type RedeemBuffCommandBody struct {
+	BuffType string `json:"buffType"`
+}
+
+// TeleportCommandBody represents the body for teleporting to or summoning a family member
+type TeleportCommandBody struct {
+	TargetId uint32 `json:"targetId"`
+	MapId    uint32 `json:"mapId"`
+}
+
+// RenameFamilyCommandBody represents the body for renaming a family
+type RenameFamilyCommandBody struct {
+	FamilyId uint32 `json:"familyId"`
+	Name     string `json:"name"`
+}
+
+// FamilyRenamedEventBody represents the body for family renamed events
+type FamilyRenamedEventBody struct {
+	FamilyId     uint32    `json:"familyId"`
+	Name         string    `json:"name"`
+	PreviousName string    `json:"previousName"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// SetFamilyTextCommandBody represents the body for setting a family precept or notice
+type SetFamilyTextCommandBody struct {
+	FamilyId uint32 `json:"familyId"`
+	Text     string `json:"text"`
+}
+
+// FamilyTextUpdatedEventBody represents the body for precept and notice updated events
+type FamilyTextUpdatedEventBody struct {
+	FamilyId  uint32    `json:"familyId"`
+	Text      string    `json:"text"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// MemberCreatedEventBody represents the body for member created events
+type MemberCreatedEventBody struct {
+	Level     uint16    `json:"level"`
+	World     byte      `json:"world"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// CooldownClearedEventBody represents the body for cooldown cleared events
+type CooldownClearedEventBody struct {
+	PreviousCooldownUntil time.Time `json:"previousCooldownUntil"`
+	Timestamp             time.Time `json:"timestamp"`
+}
+
+// MemberUpdatedEventBody represents the body for member updated events
+type MemberUpdatedEventBody struct {
+	Level         uint16    `json:"level"`
+	PreviousLevel uint16    `json:"previousLevel"`
+	World         byte      `json:"world"`
+	PreviousWorld byte      `json:"previousWorld"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// InvitationEventBody represents the body for invitation lifecycle events
+type InvitationEventBody struct {
+	InvitationId uint32    `json:"invitationId"`
+	SeniorId     uint32    `json:"seniorId"`
+	JuniorId     uint32    `json:"juniorId"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
 // Error Event Body Types
 
 // RepErrorEventBody represents the body for reputation error events
@@ -165,6 +279,14 @@ type LinkErrorEventBody struct {
 	Timestamp    time.Time `json:"timestamp"`
 }
 
+// FamilyErrorEventBody represents the body for family error events
+type FamilyErrorEventBody struct {
+	FamilyId     uint32    `json:"familyId"`
+	ErrorCode    string    `json:"errorCode"`
+	ErrorMessage string    `json:"errorMessage"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
 // Environment Variable Topic Constants
 const (
 	EnvCommandTopic     = "COMMAND_TOPIC_FAMILY"
@@ -175,11 +297,32 @@ const (
 
 // Command Type Constants
 const (
-	CommandTypeAddJunior    = "ADD_JUNIOR"
-	CommandTypeRemoveMember = "REMOVE_MEMBER"
-	CommandTypeBreakLink    = "BREAK_LINK"
-	CommandTypeAwardRep     = "AWARD_REP"
-	CommandTypeDeductRep    = "DEDUCT_REP"
+	CommandTypeCreateMember   = "CREATE_MEMBER"
+	CommandTypeAddJunior      = "ADD_JUNIOR"
+	CommandTypeRemoveMember   = "REMOVE_MEMBER"
+	CommandTypeBreakLink      = "BREAK_LINK"
+	CommandTypeLeaveSenior    = "LEAVE_SENIOR"
+	CommandTypeExpelJunior    = "EXPEL_JUNIOR"
+	CommandTypeClearCooldown  = "CLEAR_COOLDOWN"
+	CommandTypeDissolveFamily = "DISSOLVE_FAMILY"
+	CommandTypeAwardRep       = "AWARD_REP"
+	CommandTypeDeductRep      = "DEDUCT_REP"
+
+	CommandTypeRegisterKillActivity       = "REGISTER_KILL_ACTIVITY"
+	CommandTypeRegisterExpeditionActivity = "REGISTER_EXPEDITION_ACTIVITY"
+
+	CommandTypeInviteJunior      = "INVITE_JUNIOR"
+	CommandTypeAcceptInvitation  = "ACCEPT_INVITATION"
+	CommandTypeDeclineInvitation = "DECLINE_INVITATION"
+
+	CommandTypeRedeemBuff = "REDEEM_BUFF"
+
+	CommandTypeTeleportToMember = "TELEPORT_TO_MEMBER"
+	CommandTypeSummonMember     = "SUMMON_MEMBER"
+
+	CommandTypeRenameFamily = "RENAME_FAMILY"
+	CommandTypeSetPrecept   = "SET_PRECEPT"
+	CommandTypeSetNotice    = "SET_NOTICE"
 )
 
 // Event Type Constants
@@ -194,10 +337,43 @@ const (
 	EventTypeRepReset      = "REP_RESET"
 	EventTypeRepError      = "REP_ERROR"
 	EventTypeLinkError     = "LINK_ERROR"
+	EventTypeFamilyError   = "FAMILY_ERROR"
+
+	EventTypeInvitationCreated  = "INVITATION_CREATED"
+	EventTypeInvitationAccepted = "INVITATION_ACCEPTED"
+	EventTypeInvitationDeclined = "INVITATION_DECLINED"
+	EventTypeInvitationExpired  = "INVITATION_EXPIRED"
+
+	EventTypeBuffRedeemed = "BUFF_REDEEMED"
+
+	EventTypeTeleportUsed = "TELEPORT_USED"
+	EventTypeSummonUsed   = "SUMMON_USED"
+
+	EventTypeFamilyRenamed  = "FAMILY_RENAMED"
+	EventTypePreceptUpdated = "PRECEPT_UPDATED"
+	EventTypeNoticeUpdated  = "NOTICE_UPDATED"
+
+	EventTypeMemberCreated = "MEMBER_CREATED"
+	EventTypeMemberUpdated = "MEMBER_UPDATED"
+
+	EventTypeCooldownCleared = "COOLDOWN_CLEARED"
 )
 
 // Helper functions for creating typed commands and events
 
+// NewCreateMemberCommand creates a new CreateMember command
+func NewCreateMemberCommand(transactionId uuid.UUID, worldId byte, characterId uint32, level uint16) Command[CreateMemberCommandBody] {
+	return Command[CreateMemberCommandBody]{
+		TransactionId: transactionId,
+		WorldId:       worldId,
+		CharacterId:   characterId,
+		Type:          CommandTypeCreateMember,
+		Body: CreateMemberCommandBody{
+			Level: level,
+		},
+	}
+}
+
 // NewAddJuniorCommand creates a new AddJunior command
 func NewAddJuniorCommand(transactionId uuid.UUID, worldId byte, characterId uint32, juniorId uint32, seniorLevel uint16, juniorLevel uint16) Command[AddJuniorCommandBody] {
 	return Command[AddJuniorCommandBody]{
@@ -228,18 +404,62 @@ func NewRemoveMemberCommand(transactionId uuid.UUID, worldId byte, characterId u
 }
 
 // NewBreakLinkCommand creates a new BreakLink command
-func NewBreakLinkCommand(transactionId uuid.UUID, worldId byte, characterId uint32, reason string) Command[BreakLinkCommandBody] {
+func NewBreakLinkCommand(transactionId uuid.UUID, worldId byte, characterId uint32) Command[BreakLinkCommandBody] {
 	return Command[BreakLinkCommandBody]{
 		TransactionId: transactionId,
 		WorldId:       worldId,
 		CharacterId:   characterId,
 		Type:          CommandTypeBreakLink,
-		Body: BreakLinkCommandBody{
-			Reason: reason,
+		Body:          BreakLinkCommandBody{},
+	}
+}
+
+// NewLeaveSeniorCommand creates a new LeaveSenior command
+func NewLeaveSeniorCommand(transactionId uuid.UUID, worldId byte, characterId uint32) Command[LeaveSeniorCommandBody] {
+	return Command[LeaveSeniorCommandBody]{
+		TransactionId: transactionId,
+		WorldId:       worldId,
+		CharacterId:   characterId,
+		Type:          CommandTypeLeaveSenior,
+		Body:          LeaveSeniorCommandBody{},
+	}
+}
+
+// NewExpelJuniorCommand creates a new ExpelJunior command
+func NewExpelJuniorCommand(transactionId uuid.UUID, worldId byte, characterId uint32, juniorId uint32) Command[ExpelJuniorCommandBody] {
+	return Command[ExpelJuniorCommandBody]{
+		TransactionId: transactionId,
+		WorldId:       worldId,
+		CharacterId:   characterId,
+		Type:          CommandTypeExpelJunior,
+		Body: ExpelJuniorCommandBody{
+			JuniorId: juniorId,
 		},
 	}
 }
 
+// NewClearCooldownCommand creates a new ClearCooldown command
+func NewClearCooldownCommand(transactionId uuid.UUID, worldId byte, characterId uint32) Command[ClearCooldownCommandBody] {
+	return Command[ClearCooldownCommandBody]{
+		TransactionId: transactionId,
+		WorldId:       worldId,
+		CharacterId:   characterId,
+		Type:          CommandTypeClearCooldown,
+		Body:          ClearCooldownCommandBody{},
+	}
+}
+
+// NewDissolveFamilyCommand creates a new DissolveFamily command
+func NewDissolveFamilyCommand(transactionId uuid.UUID, worldId byte, characterId uint32) Command[DissolveFamilyCommandBody] {
+	return Command[DissolveFamilyCommandBody]{
+		TransactionId: transactionId,
+		WorldId:       worldId,
+		CharacterId:   characterId,
+		Type:          CommandTypeDissolveFamily,
+		Body:          DissolveFamilyCommandBody{},
+	}
+}
+
 // NewDeductRepCommand creates a new DeductRep command
 func NewDeductRepCommand(transactionId uuid.UUID, worldId byte, characterId uint32, amount uint32, reason string) Command[DeductRepCommandBody] {
 	return Command[DeductRepCommandBody]{
@@ -254,6 +474,104 @@ func NewDeductRepCommand(transactionId uuid.UUID, worldId byte, characterId uint
 	}
 }
 
+// NewRegisterKillActivityCommand creates a new RegisterKillActivity command
+func NewRegisterKillActivityCommand(transactionId uuid.UUID, worldId byte, characterId uint32, killCount uint32) Command[RegisterKillActivityCommandBody] {
+	return Command[RegisterKillActivityCommandBody]{
+		TransactionId: transactionId,
+		WorldId:       worldId,
+		CharacterId:   characterId,
+		Type:          CommandTypeRegisterKillActivity,
+		Body: RegisterKillActivityCommandBody{
+			KillCount: killCount,
+			Timestamp: time.Now(),
+		},
+	}
+}
+
+// NewRegisterExpeditionActivityCommand creates a new RegisterExpeditionActivity command
+func NewRegisterExpeditionActivityCommand(transactionId uuid.UUID, worldId byte, characterId uint32, coinReward uint32) Command[RegisterExpeditionActivityCommandBody] {
+	return Command[RegisterExpeditionActivityCommandBody]{
+		TransactionId: transactionId,
+		WorldId:       worldId,
+		CharacterId:   characterId,
+		Type:          CommandTypeRegisterExpeditionActivity,
+		Body: RegisterExpeditionActivityCommandBody{
+			CoinReward: coinReward,
+			Timestamp:  time.Now(),
+		},
+	}
+}
+
+// NewInviteJuniorCommand creates a new InviteJunior command
+func NewInviteJuniorCommand(transactionId uuid.UUID, worldId byte, characterId uint32, juniorId uint32, seniorLevel uint16, juniorLevel uint16) Command[InviteJuniorCommandBody] {
+	return Command[InviteJuniorCommandBody]{
+		TransactionId: transactionId,
+		WorldId:       worldId,
+		CharacterId:   characterId,
+		Type:          CommandTypeInviteJunior,
+		Body: InviteJuniorCommandBody{
+			JuniorId:    juniorId,
+			SeniorLevel: seniorLevel,
+			JuniorLevel: juniorLevel,
+		},
+	}
+}
+
+// NewRedeemBuffCommand creates a new RedeemBuff command
+func NewRedeemBuffCommand(transactionId uuid.UUID, worldId byte, characterId uint32, buffType string) Command[RedeemBuffCommandBody] {
+	return Command[RedeemBuffCommandBody]{
+		TransactionId: transactionId,
+		WorldId:       worldId,
+		CharacterId:   characterId,
+		Type:          CommandTypeRedeemBuff,
+		Body: RedeemBuffCommandBody{
+			BuffType: buffType,
+		},
+	}
+}
+
+// NewTeleportCommand creates a new TeleportToMember or SummonMember command
+func NewTeleportCommand(commandType string, transactionId uuid.UUID, worldId byte, characterId uint32, targetId uint32, mapId uint32) Command[TeleportCommandBody] {
+	return Command[TeleportCommandBody]{
+		TransactionId: transactionId,
+		WorldId:       worldId,
+		CharacterId:   characterId,
+		Type:          commandType,
+		Body: TeleportCommandBody{
+			TargetId: targetId,
+			MapId:    mapId,
+		},
+	}
+}
+
+// NewRenameFamilyCommand creates a new RenameFamily command
+func NewRenameFamilyCommand(transactionId uuid.UUID, worldId byte, characterId uint32, familyId uint32, name string) Command[RenameFamilyCommandBody] {
+	return Command[RenameFamilyCommandBody]{
+		TransactionId: transactionId,
+		WorldId:       worldId,
+		CharacterId:   characterId,
+		Type:          CommandTypeRenameFamily,
+		Body: RenameFamilyCommandBody{
+			FamilyId: familyId,
+			Name:     name,
+		},
+	}
+}
+
+// NewSetFamilyTextCommand creates a new SetPrecept or SetNotice command
+func NewSetFamilyTextCommand(commandType string, transactionId uuid.UUID, worldId byte, characterId uint32, familyId uint32, text string) Command[SetFamilyTextCommandBody] {
+	return Command[SetFamilyTextCommandBody]{
+		TransactionId: transactionId,
+		WorldId:       worldId,
+		CharacterId:   characterId,
+		Type:          commandType,
+		Body: SetFamilyTextCommandBody{
+			FamilyId: familyId,
+			Text:     text,
+		},
+	}
+}
+
 // NewLinkCreatedEvent creates a new LinkCreated event
 func NewLinkCreatedEvent(worldId byte, characterId uint32, seniorId uint32, juniorId uint32) Event[LinkCreatedEventBody] {
 	return Event[LinkCreatedEventBody]{
@@ -268,6 +586,24 @@ func NewLinkCreatedEvent(worldId byte, characterId uint32, seniorId uint32, juni
 	}
 }
 
+// NewLinkSeveredEvent creates a new LinkBroken event for a junior leaving or being expelled, carrying the rep paid
+// and the end of the junior's cooldown, if one was started
+func NewLinkSeveredEvent(worldId byte, characterId uint32, seniorId uint32, juniorId uint32, reason string, repCost uint32, cooldownUntil *time.Time) Event[LinkBrokenEventBody] {
+	return Event[LinkBrokenEventBody]{
+		WorldId:     worldId,
+		CharacterId: characterId,
+		Type:        EventTypeLinkBroken,
+		Body: LinkBrokenEventBody{
+			SeniorId:      seniorId,
+			JuniorId:      juniorId,
+			Reason:        reason,
+			RepCost:       repCost,
+			CooldownUntil: cooldownUntil,
+			Timestamp:     time.Now(),
+		},
+	}
+}
+
 // NewLinkBrokenEvent creates a new LinkBroken event
 func NewLinkBrokenEvent(worldId byte, characterId uint32, seniorId uint32, juniorId uint32, reason string) Event[LinkBrokenEventBody] {
 	return Event[LinkBrokenEventBody]{
@@ -284,16 +620,17 @@ func NewLinkBrokenEvent(worldId byte, characterId uint32, seniorId uint32, junio
 }
 
 // NewRepGainedEvent creates a new RepGained event
-func NewRepGainedEvent(worldId byte, characterId uint32, repGained uint32, dailyRep uint32, source string) Event[RepGainedEventBody] {
+func NewRepGainedEvent(worldId byte, characterId uint32, repGained uint32, dailyRep uint32, source string, sourceCharacterId uint32) Event[RepGainedEventBody] {
 	return Event[RepGainedEventBody]{
 		WorldId:     worldId,
 		CharacterId: characterId,
 		Type:        EventTypeRepGained,
 		Body: RepGainedEventBody{
-			RepGained: repGained,
-			DailyRep:  dailyRep,
-			Source:    source,
-			Timestamp: time.Now(),
+			RepGained:         repGained,
+			DailyRep:          dailyRep,
+			Source:            source,
+			SourceCharacterId: sourceCharacterId,
+			Timestamp:         time.Now(),
 		},
 	}
 }
@@ -342,3 +679,137 @@ func NewLinkErrorEvent(worldId byte, characterId uint32, seniorId uint32, junior
 		},
 	}
 }
+
+// NewFamilyErrorEvent creates a new FamilyError event
+func NewFamilyErrorEvent(worldId byte, characterId uint32, familyId uint32, errorCode string, errorMessage string) Event[FamilyErrorEventBody] {
+	return Event[FamilyErrorEventBody]{
+		WorldId:     worldId,
+		CharacterId: characterId,
+		Type:        EventTypeFamilyError,
+		Body: FamilyErrorEventBody{
+			FamilyId:     familyId,
+			ErrorCode:    errorCode,
+			ErrorMessage: errorMessage,
+			Timestamp:    time.Now(),
+		},
+	}
+}
+
+// NewInvitationEvent creates a new invitation lifecycle event of the given type
+func NewInvitationEvent(eventType string, worldId byte, characterId uint32, invitationId uint32, seniorId uint32, juniorId uint32, expiresAt time.Time) Event[InvitationEventBody] {
+	return Event[InvitationEventBody]{
+		WorldId:     worldId,
+		CharacterId: characterId,
+		Type:        eventType,
+		Body: InvitationEventBody{
+			InvitationId: invitationId,
+			SeniorId:     seniorId,
+			JuniorId:     juniorId,
+			ExpiresAt:    expiresAt,
+			Timestamp:    time.Now(),
+		},
+	}
+}
+
+// NewBuffRedeemedEvent creates a new BuffRedeemed event
+func NewBuffRedeemedEvent(worldId byte, characterId uint32, buffType string, repCost uint32, duration uint32) Event[BuffRedeemedEventBody] {
+	return Event[BuffRedeemedEventBody]{
+		WorldId:     worldId,
+		CharacterId: characterId,
+		Type:        EventTypeBuffRedeemed,
+		Body: BuffRedeemedEventBody{
+			BuffType:  buffType,
+			RepCost:   repCost,
+			Duration:  duration,
+			Timestamp: time.Now(),
+		},
+	}
+}
+
+// NewTeleportUsedEvent creates a new TeleportUsed or SummonUsed event
+func NewTeleportUsedEvent(eventType string, worldId byte, characterId uint32, targetId uint32, repCost uint32, mapId uint32) Event[TeleportUsedEventBody] {
+	return Event[TeleportUsedEventBody]{
+		WorldId:     worldId,
+		CharacterId: characterId,
+		Type:        eventType,
+		Body: TeleportUsedEventBody{
+			TargetId:  targetId,
+			RepCost:   repCost,
+			World:     worldId,
+			Map:       mapId,
+			Timestamp: time.Now(),
+		},
+	}
+}
+
+// NewFamilyRenamedEvent creates a new FamilyRenamed event
+func NewFamilyRenamedEvent(worldId byte, characterId uint32, familyId uint32, name string, previousName string) Event[FamilyRenamedEventBody] {
+	return Event[FamilyRenamedEventBody]{
+		WorldId:     worldId,
+		CharacterId: characterId,
+		Type:        EventTypeFamilyRenamed,
+		Body: FamilyRenamedEventBody{
+			FamilyId:     familyId,
+			Name:         name,
+			PreviousName: previousName,
+			Timestamp:    time.Now(),
+		},
+	}
+}
+
+// NewFamilyTextUpdatedEvent creates a new PreceptUpdated or NoticeUpdated event
+func NewFamilyTextUpdatedEvent(eventType string, worldId byte, characterId uint32, familyId uint32, text string) Event[FamilyTextUpdatedEventBody] {
+	return Event[FamilyTextUpdatedEventBody]{
+		WorldId:     worldId,
+		CharacterId: characterId,
+		Type:        eventType,
+		Body: FamilyTextUpdatedEventBody{
+			FamilyId:  familyId,
+			Text:      text,
+			Timestamp: time.Now(),
+		},
+	}
+}
+
+// NewMemberCreatedEvent creates a new MemberCreated event
+func NewMemberCreatedEvent(worldId byte, characterId uint32, level uint16) Event[MemberCreatedEventBody] {
+	return Event[MemberCreatedEventBody]{
+		WorldId:     worldId,
+		CharacterId: characterId,
+		Type:        EventTypeMemberCreated,
+		Body: MemberCreatedEventBody{
+			Level:     level,
+			World:     worldId,
+			Timestamp: time.Now(),
+		},
+	}
+}
+
+// NewCooldownClearedEvent creates a new CooldownCleared event
+func NewCooldownClearedEvent(worldId byte, characterId uint32, previousCooldownUntil time.Time) Event[CooldownClearedEventBody] {
+	return Event[CooldownClearedEventBody]{
+		WorldId:     worldId,
+		CharacterId: characterId,
+		Type:        EventTypeCooldownCleared,
+		Body: CooldownClearedEventBody{
+			PreviousCooldownUntil: previousCooldownUntil,
+			Timestamp:             time.Now(),
+		},
+	}
+}
+
+// NewMemberUpdatedEvent creates a new MemberUpdated event
+func NewMemberUpdatedEvent(worldId byte, characterId uint32, level uint16, previousLevel uint16, previousWorld byte) Event[MemberUpdatedEventBody] {
+	return Event[MemberUpdatedEventBody]{
+		WorldId:     worldId,
+		CharacterId: characterId,
+		Type:        EventTypeMemberUpdated,
+		Body: MemberUpdatedEventBody{
+			Level:         level,
+			PreviousLevel: previousLevel,
+			World:         worldId,
+			PreviousWorld: previousWorld,
+			Timestamp:     time.Now(),
+		},
+	}
+}
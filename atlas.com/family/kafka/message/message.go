@@ -1,6 +1,7 @@
 package message
 
 import (
+	"atlas-family/kafka/message/family"
 	"atlas-family/kafka/producer"
 
 	"github.com/Chronicle20/atlas-model/model"
@@ -30,20 +31,27 @@ func (b *Buffer) GetAll() map[string][]kafka.Message {
 	return b.buffer
 }
 
+// Flush emits the contents of a buffer once the operation which filled it has finished. A failed operation emits
+// only its error events, discarding any buffered success events.
+func Flush(p producer.Provider, b *Buffer, err error) error {
+	if err != nil {
+		if ms, ok := b.GetAll()[family.EnvEventTopicErrors]; ok {
+			_ = p(family.EnvEventTopicErrors)(model.FixedProvider(ms))
+		}
+		return err
+	}
+	for t, ms := range b.GetAll() {
+		if err = p(t)(model.FixedProvider(ms)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func Emit(p producer.Provider) func(f func(buf *Buffer) error) error {
 	return func(f func(buf *Buffer) error) error {
 		b := NewBuffer()
-		err := f(b)
-		if err != nil {
-			return err
-		}
-		for t, ms := range b.GetAll() {
-			err = p(t)(model.FixedProvider(ms))
-			if err != nil {
-				return err
-			}
-		}
-		return nil
+		return Flush(p, b, f(b))
 	}
 }
 
@@ -52,15 +60,7 @@ func EmitWithResult[M any, B any](p producer.Provider) func(func(*Buffer) func(B
 		return func(input B) (M, error) {
 			var buf = NewBuffer()
 			result, err := f(buf)(input)
-			if err != nil {
-				return result, err
-			}
-			for t, ms := range buf.GetAll() {
-				if err = p(t)(model.FixedProvider(ms)); err != nil {
-					return result, err
-				}
-			}
-			return result, nil
+			return result, Flush(p, buf, err)
 		}
 	}
 }
@@ -0,0 +1,25 @@
+package ledger
+
+import (
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Append records a ledger entry. Entries are never updated or deleted once written.
+func Append(db *gorm.DB, log logrus.FieldLogger) func(entry Entry) model.Provider[Entity] {
+	return func(entry Entry) model.Provider[Entity] {
+		log.WithFields(logrus.Fields{
+			"characterId":   entry.CharacterId(),
+			"amount":        entry.Amount(),
+			"direction":     entry.Direction(),
+			"transactionId": entry.TransactionId(),
+		}).Debug("Appending reputation ledger entry")
+
+		entity := ToEntity(entry)
+		if err := db.Create(&entity).Error; err != nil {
+			return model.ErrorProvider[Entity](err)
+		}
+		return model.FixedProvider(entity)
+	}
+}
@@ -0,0 +1,84 @@
+package ledger
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Builder constructs immutable ledger entries
+type Builder struct {
+	id            uint32
+	tenantId      uuid.UUID
+	characterId   uint32
+	amount        uint32
+	direction     string
+	reason        string
+	transactionId uuid.UUID
+	balanceAfter  uint32
+	createdAt     time.Time
+}
+
+// NewBuilder creates a new entry builder with required parameters
+func NewBuilder(tenantId uuid.UUID, characterId uint32, amount uint32, direction string) *Builder {
+	return &Builder{
+		tenantId:    tenantId,
+		characterId: characterId,
+		amount:      amount,
+		direction:   direction,
+		createdAt:   time.Now(),
+	}
+}
+
+func (b *Builder) SetId(id uint32) *Builder {
+	b.id = id
+	return b
+}
+
+func (b *Builder) SetReason(reason string) *Builder {
+	b.reason = reason
+	return b
+}
+
+func (b *Builder) SetTransactionId(transactionId uuid.UUID) *Builder {
+	b.transactionId = transactionId
+	return b
+}
+
+func (b *Builder) SetBalanceAfter(balanceAfter uint32) *Builder {
+	b.balanceAfter = balanceAfter
+	return b
+}
+
+func (b *Builder) SetCreatedAt(createdAt time.Time) *Builder {
+	b.createdAt = createdAt
+	return b
+}
+
+// Build validates the entry and constructs the final immutable Entry
+func (b *Builder) Build() (Entry, error) {
+	if b.characterId == 0 {
+		return Entry{}, ErrInvalidCharacterId
+	}
+	if b.tenantId == uuid.Nil {
+		return Entry{}, ErrInvalidTenantId
+	}
+	if b.amount == 0 {
+		return Entry{}, ErrInvalidAmount
+	}
+	if b.direction != DirectionCredit && b.direction != DirectionDebit {
+		return Entry{}, ErrInvalidDirection
+	}
+
+	return Entry{
+		id:            b.id,
+		tenantId:      b.tenantId,
+		characterId:   b.characterId,
+		amount:        b.amount,
+		direction:     b.direction,
+		reason:        b.reason,
+		transactionId: b.transactionId,
+		balanceAfter:  b.balanceAfter,
+		createdAt:     b.createdAt,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package ledger
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Entity represents the GORM-compatible database representation of a reputation ledger entry
+type Entity struct {
+	ID            uint32    `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantId      uuid.UUID `gorm:"type:uuid;not null;index:idx_family_rep_ledger_member,priority:1" json:"tenantId"`
+	CharacterId   uint32    `gorm:"not null;index:idx_family_rep_ledger_member,priority:2" json:"characterId"`
+	Amount        uint32    `gorm:"not null" json:"amount"`
+	Direction     string    `gorm:"not null" json:"direction"`
+	Reason        string    `gorm:"not null;default:''" json:"reason"`
+	TransactionId uuid.UUID `gorm:"type:uuid;index" json:"transactionId"`
+	BalanceAfter  uint32    `gorm:"not null" json:"balanceAfter"`
+	CreatedAt     time.Time `gorm:"not null;index" json:"createdAt"`
+}
+
+// TableName specifies the table name for the Entity
+func (Entity) TableName() string {
+	return "family_rep_ledger"
+}
+
+// Migration creates the family_rep_ledger table
+func Migration(db *gorm.DB) error {
+	return db.AutoMigrate(&Entity{})
+}
+
+// Make transforms an Entity into an immutable Entry
+func Make(entity Entity) (Entry, error) {
+	return NewBuilder(entity.TenantId, entity.CharacterId, entity.Amount, entity.Direction).
+		SetId(entity.ID).
+		SetReason(entity.Reason).
+		SetTransactionId(entity.TransactionId).
+		SetBalanceAfter(entity.BalanceAfter).
+		SetCreatedAt(entity.CreatedAt).
+		Build()
+}
+
+// ToEntity transforms an immutable Entry into an Entity
+func ToEntity(e Entry) Entity {
+	return Entity{
+		ID:            e.id,
+		TenantId:      e.tenantId,
+		CharacterId:   e.characterId,
+		Amount:        e.amount,
+		Direction:     e.direction,
+		Reason:        e.reason,
+		TransactionId: e.transactionId,
+		BalanceAfter:  e.balanceAfter,
+		CreatedAt:     e.createdAt,
+	}
+}
@@ -0,0 +1,135 @@
+package ledger
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Directions of a reputation change
+const (
+	DirectionCredit = "CREDIT"
+	DirectionDebit  = "DEBIT"
+)
+
+// Page size bounds for history queries
+const (
+	DefaultPageSize = uint32(50)
+	MaxPageSize     = uint32(200)
+)
+
+// Validation errors
+var (
+	ErrInvalidDirection   = errors.New("invalid ledger direction")
+	ErrInvalidAmount      = errors.New("ledger amount must be positive")
+	ErrInvalidCharacterId = errors.New("invalid character ID")
+	ErrInvalidTenantId    = errors.New("invalid tenant ID")
+)
+
+// Entry represents an immutable record of a single change to a member's reputation balance
+type Entry struct {
+	id            uint32
+	tenantId      uuid.UUID
+	characterId   uint32
+	amount        uint32
+	direction     string
+	reason        string
+	transactionId uuid.UUID
+	balanceAfter  uint32
+	createdAt     time.Time
+}
+
+func (e Entry) Id() uint32 {
+	return e.id
+}
+
+func (e Entry) TenantId() uuid.UUID {
+	return e.tenantId
+}
+
+func (e Entry) CharacterId() uint32 {
+	return e.characterId
+}
+
+func (e Entry) Amount() uint32 {
+	return e.amount
+}
+
+func (e Entry) Direction() string {
+	return e.direction
+}
+
+// Reason returns the source of a credit or the reason for a debit
+func (e Entry) Reason() string {
+	return e.reason
+}
+
+// TransactionId returns the transaction which caused the change, or uuid.Nil when none was supplied
+func (e Entry) TransactionId() uuid.UUID {
+	return e.transactionId
+}
+
+// BalanceAfter returns the member's rep balance once the change was applied
+func (e Entry) BalanceAfter() uint32 {
+	return e.balanceAfter
+}
+
+func (e Entry) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// IsCredit returns true if the entry increased the balance
+func (e Entry) IsCredit() bool {
+	return e.direction == DirectionCredit
+}
+
+// Filter narrows a history query. Zero times are unbounded and a zero cursor starts from the newest entry.
+type Filter struct {
+	From   time.Time
+	To     time.Time
+	Cursor uint32
+	Limit  uint32
+}
+
+// PageSize returns the requested limit clamped to the allowed bounds
+func (f Filter) PageSize() uint32 {
+	if f.Limit == 0 {
+		return DefaultPageSize
+	}
+	if f.Limit > MaxPageSize {
+		return MaxPageSize
+	}
+	return f.Limit
+}
+
+// Page is a slice of a member's history, newest first
+type Page struct {
+	characterId uint32
+	entries     []Entry
+	nextCursor  *uint32
+}
+
+// NewPage creates a page, deriving the next cursor when more entries than the page size were fetched
+func NewPage(characterId uint32, entries []Entry, pageSize uint32) Page {
+	p := Page{characterId: characterId, entries: entries}
+	if uint32(len(entries)) > pageSize {
+		p.entries = entries[:pageSize]
+		cursor := p.entries[len(p.entries)-1].Id()
+		p.nextCursor = &cursor
+	}
+	return p
+}
+
+func (p Page) CharacterId() uint32 {
+	return p.characterId
+}
+
+func (p Page) Entries() []Entry {
+	return p.entries
+}
+
+// NextCursor returns the cursor for the following page, or nil when this is the last page
+func (p Page) NextCursor() *uint32 {
+	return p.nextCursor
+}
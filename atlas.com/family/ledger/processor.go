@@ -0,0 +1,42 @@
+package ledger
+
+import (
+	"context"
+
+	"github.com/Chronicle20/atlas-model/model"
+	tenant "github.com/Chronicle20/atlas-tenant"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Processor interface defines the reputation ledger queries
+type Processor interface {
+	GetHistory(characterId uint32, filter Filter) (Page, error)
+}
+
+// ProcessorImpl implements the Processor interface
+type ProcessorImpl struct {
+	log logrus.FieldLogger
+	ctx context.Context
+	db  *gorm.DB
+	t   tenant.Model
+}
+
+// NewProcessor creates a new processor instance
+func NewProcessor(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) Processor {
+	return &ProcessorImpl{
+		log: l,
+		ctx: ctx,
+		db:  db,
+		t:   tenant.MustFromContext(ctx),
+	}
+}
+
+// GetHistory returns one page of a member's reputation history
+func (p *ProcessorImpl) GetHistory(characterId uint32, filter Filter) (Page, error) {
+	entries, err := model.SliceMap(Make)(GetHistoryProvider(p.t.Id(), characterId, filter)(p.db))(model.ParallelMap())()
+	if err != nil {
+		return Page{}, err
+	}
+	return NewPage(characterId, entries, filter.PageSize()), nil
+}
@@ -0,0 +1,32 @@
+package ledger
+
+import (
+	"atlas-family/database"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetHistoryProvider retrieves a member's ledger entries newest first, fetching one entry beyond the page size
+// so callers can tell whether a further page exists
+func GetHistoryProvider(tenantId uuid.UUID, characterId uint32, filter Filter) database.EntityProvider[[]Entity] {
+	return func(db *gorm.DB) model.Provider[[]Entity] {
+		query := db.Where("tenant_id = ? AND character_id = ?", tenantId, characterId)
+		if !filter.From.IsZero() {
+			query = query.Where("created_at >= ?", filter.From)
+		}
+		if !filter.To.IsZero() {
+			query = query.Where("created_at < ?", filter.To)
+		}
+		if filter.Cursor != 0 {
+			query = query.Where("id < ?", filter.Cursor)
+		}
+
+		var entities []Entity
+		if err := query.Order("id DESC").Limit(int(filter.PageSize()) + 1).Find(&entities).Error; err != nil {
+			return model.ErrorProvider[[]Entity](err)
+		}
+		return model.FixedProvider(entities)
+	}
+}
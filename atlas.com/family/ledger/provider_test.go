@@ -0,0 +1,112 @@
+package ledger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newTestDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := Migration(db); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	return db
+}
+
+// seedEntries appends one credit per hour for a character, starting at the given time
+func seedEntries(t *testing.T, db *gorm.DB, tenantId uuid.UUID, characterId uint32, start time.Time, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		entity := Entity{
+			TenantId:     tenantId,
+			CharacterId:  characterId,
+			Amount:       10,
+			Direction:    DirectionCredit,
+			Reason:       "MOB_KILL",
+			BalanceAfter: uint32(10 * (i + 1)),
+			CreatedAt:    start.Add(time.Duration(i) * time.Hour),
+		}
+		if err := db.Create(&entity).Error; err != nil {
+			t.Fatalf("Failed to seed ledger entry: %v", err)
+		}
+	}
+}
+
+func getPage(t *testing.T, db *gorm.DB, tenantId uuid.UUID, characterId uint32, filter Filter) Page {
+	t.Helper()
+	entities, err := GetHistoryProvider(tenantId, characterId, filter)(db)()
+	if err != nil {
+		t.Fatalf("Failed to get history: %v", err)
+	}
+	entries := make([]Entry, 0, len(entities))
+	for _, e := range entities {
+		entry, err := Make(e)
+		if err != nil {
+			t.Fatalf("Failed to make entry: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	return NewPage(characterId, entries, filter.PageSize())
+}
+
+func TestGetHistoryProvider_CursorPagination(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	seedEntries(t, db, tenantId, 1000, start, 5)
+	seedEntries(t, db, tenantId, 2000, start, 3)
+	seedEntries(t, db, uuid.New(), 1000, start, 3)
+
+	seen := make([]uint32, 0)
+	filter := Filter{Limit: 2}
+	for pages := 0; pages < 5; pages++ {
+		page := getPage(t, db, tenantId, 1000, filter)
+		for _, e := range page.Entries() {
+			if e.CharacterId() != 1000 || e.TenantId() != tenantId {
+				t.Fatalf("Entry %d belongs to another member", e.Id())
+			}
+			seen = append(seen, e.BalanceAfter())
+		}
+		if page.NextCursor() == nil {
+			break
+		}
+		filter.Cursor = *page.NextCursor()
+	}
+
+	expected := []uint32{50, 40, 30, 20, 10}
+	if len(seen) != len(expected) {
+		t.Fatalf("Expected %d entries across pages, got %v", len(expected), seen)
+	}
+	for i := range expected {
+		if seen[i] != expected[i] {
+			t.Fatalf("Expected entries newest first %v, got %v", expected, seen)
+		}
+	}
+}
+
+func TestGetHistoryProvider_TimeRange(t *testing.T) {
+	db := newTestDatabase(t)
+	tenantId := uuid.New()
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	seedEntries(t, db, tenantId, 1000, start, 5)
+
+	page := getPage(t, db, tenantId, 1000, Filter{From: start.Add(time.Hour), To: start.Add(3 * time.Hour)})
+	if len(page.Entries()) != 2 {
+		t.Fatalf("Expected 2 entries in range, got %d", len(page.Entries()))
+	}
+	if page.Entries()[0].BalanceAfter() != 30 || page.Entries()[1].BalanceAfter() != 20 {
+		t.Errorf("Unexpected entries in range")
+	}
+	if page.NextCursor() != nil {
+		t.Errorf("Expected no further page")
+	}
+}
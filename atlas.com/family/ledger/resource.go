@@ -0,0 +1,89 @@
+package ledger
+
+import (
+	"atlas-family/rest"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Chronicle20/atlas-rest/server"
+	"github.com/gorilla/mux"
+	"github.com/jtumidanski/api2go/jsonapi"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// InitResource registers all ledger-related REST endpoints
+func InitResource(si jsonapi.ServerInformation) func(db *gorm.DB) server.RouteInitializer {
+	return func(db *gorm.DB) server.RouteInitializer {
+		return func(router *mux.Router, l logrus.FieldLogger) {
+			router.HandleFunc("/families/{characterId}/reputation/history", rest.RegisterHandler(l)(si)("get_reputation_history", getHistoryHandler(db))).Methods(http.MethodGet)
+		}
+	}
+}
+
+// getHistoryHandler handles GET /families/{characterId}/reputation/history
+func getHistoryHandler(db *gorm.DB) func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+	return func(d *rest.HandlerDependency, c *rest.HandlerContext) http.HandlerFunc {
+		return rest.ParseCharacterId(d.Logger(), func(characterId uint32) http.HandlerFunc {
+			return func(w http.ResponseWriter, r *http.Request) {
+				filter, err := parseFilter(r.URL.Query())
+				if err != nil {
+					rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+					return
+				}
+
+				page, err := NewProcessor(d.Logger(), d.Context(), db).GetHistory(characterId, filter)
+				if err != nil {
+					d.Logger().WithError(err).Error("Failed to get reputation history")
+					rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+					return
+				}
+
+				rm, err := Transform(page)
+				if err != nil {
+					d.Logger().WithError(err).Error("Failed to transform reputation history to REST model")
+					rest.WriteErrorResponse(w, http.StatusInternalServerError, "Internal server error")
+					return
+				}
+
+				query := r.URL.Query()
+				queryParams := jsonapi.ParseQueryFields(&query)
+				server.MarshalResponse[RestModel](d.Logger())(w)(c.ServerInformation())(queryParams)(rm)
+			}
+		})
+	}
+}
+
+// parseFilter reads the optional from, to, cursor and limit query parameters
+func parseFilter(query url.Values) (Filter, error) {
+	var filter Filter
+	var err error
+	if value := query.Get("from"); value != "" {
+		if filter.From, err = time.Parse(time.RFC3339, value); err != nil {
+			return Filter{}, errors.New("from must be an RFC 3339 timestamp")
+		}
+	}
+	if value := query.Get("to"); value != "" {
+		if filter.To, err = time.Parse(time.RFC3339, value); err != nil {
+			return Filter{}, errors.New("to must be an RFC 3339 timestamp")
+		}
+	}
+	if value := query.Get("cursor"); value != "" {
+		cursor, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return Filter{}, errors.New("cursor must be a non-negative integer")
+		}
+		filter.Cursor = uint32(cursor)
+	}
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return Filter{}, errors.New("limit must be a non-negative integer")
+		}
+		filter.Limit = uint32(limit)
+	}
+	return filter, nil
+}
@@ -0,0 +1,65 @@
+package ledger
+
+import (
+	"strconv"
+	"time"
+)
+
+// RestEntry represents a ledger entry in REST format
+type RestEntry struct {
+	Id            uint32 `json:"id"`
+	Amount        uint32 `json:"amount"`
+	Direction     string `json:"direction"`
+	Reason        string `json:"reason"`
+	TransactionId string `json:"transactionId"`
+	BalanceAfter  uint32 `json:"balanceAfter"`
+	CreatedAt     string `json:"createdAt"`
+}
+
+// RestModel represents a page of a member's reputation history in REST/JSON:API format
+type RestModel struct {
+	Id         string      `json:"-"`
+	Entries    []RestEntry `json:"entries"`
+	NextCursor *string     `json:"nextCursor,omitempty"`
+}
+
+// GetName returns the resource type for JSON:API compatibility
+func (r RestModel) GetName() string {
+	return "familyRepHistories"
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r RestModel) GetID() string {
+	return r.Id
+}
+
+// TransformEntry converts a domain entry to REST representation
+func TransformEntry(e Entry) RestEntry {
+	return RestEntry{
+		Id:            e.Id(),
+		Amount:        e.Amount(),
+		Direction:     e.Direction(),
+		Reason:        e.Reason(),
+		TransactionId: e.TransactionId().String(),
+		BalanceAfter:  e.BalanceAfter(),
+		CreatedAt:     e.CreatedAt().Format(time.RFC3339),
+	}
+}
+
+// Transform converts a domain page to REST representation
+func Transform(p Page) (RestModel, error) {
+	entries := make([]RestEntry, 0, len(p.Entries()))
+	for _, e := range p.Entries() {
+		entries = append(entries, TransformEntry(e))
+	}
+
+	rm := RestModel{
+		Id:      strconv.FormatUint(uint64(p.CharacterId()), 10),
+		Entries: entries,
+	}
+	if p.NextCursor() != nil {
+		cursor := strconv.FormatUint(uint64(*p.NextCursor()), 10)
+		rm.NextCursor = &cursor
+	}
+	return rm, nil
+}
@@ -0,0 +1,20 @@
+package location
+
+// Model is where a character currently is
+type Model struct {
+	worldId byte
+	mapId   uint32
+}
+
+// NewModel creates a location on the given world and map
+func NewModel(worldId byte, mapId uint32) Model {
+	return Model{worldId: worldId, mapId: mapId}
+}
+
+func (m Model) WorldId() byte {
+	return m.worldId
+}
+
+func (m Model) MapId() uint32 {
+	return m.mapId
+}
@@ -0,0 +1,113 @@
+package location
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrLocationNotFound is returned when a character's location cannot be resolved
+var ErrLocationNotFound = errors.New("character location not found")
+
+// LocationProvider resolves where a character currently is
+type LocationProvider interface {
+	GetLocation(l logrus.FieldLogger, ctx context.Context, characterId uint32) (Model, error)
+}
+
+// RestProvider resolves locations from the character service
+type RestProvider struct {
+}
+
+// NewRestProvider creates a provider backed by the character service
+func NewRestProvider() RestProvider {
+	return RestProvider{}
+}
+
+func (p RestProvider) GetLocation(l logrus.FieldLogger, ctx context.Context, characterId uint32) (Model, error) {
+	rm, err := requestById(characterId)(l, ctx)
+	if err != nil {
+		return Model{}, err
+	}
+	return Extract(rm)
+}
+
+// MemoryProvider holds locations in memory. It stands in for the character service in tests.
+type MemoryProvider struct {
+	mu        sync.RWMutex
+	locations map[uint32]Model
+	fallback  *Model
+}
+
+// NewMemoryProvider creates a provider holding no locations
+func NewMemoryProvider() *MemoryProvider {
+	return &MemoryProvider{locations: make(map[uint32]Model)}
+}
+
+// Set places a character at a location
+func (p *MemoryProvider) Set(characterId uint32, m Model) *MemoryProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.locations[characterId] = m
+	return p
+}
+
+// SetFallback places every character without a location of their own at m
+func (p *MemoryProvider) SetFallback(m Model) *MemoryProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.fallback = &m
+	return p
+}
+
+func (p *MemoryProvider) GetLocation(_ logrus.FieldLogger, _ context.Context, characterId uint32) (Model, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if m, ok := p.locations[characterId]; ok {
+		return m, nil
+	}
+	if p.fallback != nil {
+		return *p.fallback, nil
+	}
+	return Model{}, ErrLocationNotFound
+}
+
+// knownProvider answers with a location supplied by the caller for one character, resolving every other character
+// through next
+type knownProvider struct {
+	next        LocationProvider
+	characterId uint32
+	location    Model
+}
+
+// WithKnown returns a provider which answers with m for the character, resolving every other character through next
+func WithKnown(next LocationProvider, characterId uint32, m Model) LocationProvider {
+	return knownProvider{next: next, characterId: characterId, location: m}
+}
+
+func (p knownProvider) GetLocation(l logrus.FieldLogger, ctx context.Context, characterId uint32) (Model, error) {
+	if characterId == p.characterId {
+		return p.location, nil
+	}
+	return p.next.GetLocation(l, ctx, characterId)
+}
+
+var (
+	defaultMu       sync.RWMutex
+	defaultProvider LocationProvider = NewRestProvider()
+)
+
+// Default returns the provider used to resolve locations, the character service unless replaced with SetDefault
+func Default() LocationProvider {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultProvider
+}
+
+// SetDefault replaces the provider returned by Default
+func SetDefault(p LocationProvider) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultProvider = p
+}
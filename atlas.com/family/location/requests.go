@@ -0,0 +1,21 @@
+package location
+
+import (
+	"atlas-family/rest"
+	"fmt"
+
+	"github.com/Chronicle20/atlas-rest/requests"
+)
+
+const (
+	Resource = "characters"
+	ById     = Resource + "/%d"
+)
+
+func getBaseRequest() string {
+	return requests.RootUrl("CHARACTERS")
+}
+
+func requestById(characterId uint32) requests.Request[RestModel] {
+	return rest.MakeGetRequest[RestModel](fmt.Sprintf(getBaseRequest()+ById, characterId))
+}
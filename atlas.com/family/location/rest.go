@@ -0,0 +1,37 @@
+package location
+
+import (
+	"strconv"
+)
+
+// RestModel is the part of the character service's character resource describing where the character is
+type RestModel struct {
+	Id      uint32 `json:"-"`
+	WorldId byte   `json:"worldId"`
+	MapId   uint32 `json:"mapId"`
+}
+
+// GetName returns the resource type for JSON:API compatibility
+func (r RestModel) GetName() string {
+	return "characters"
+}
+
+// GetID returns the ID for JSON:API compatibility
+func (r RestModel) GetID() string {
+	return strconv.Itoa(int(r.Id))
+}
+
+// SetID sets the ID for JSON:API compatibility
+func (r *RestModel) SetID(id string) error {
+	v, err := strconv.Atoi(id)
+	if err != nil {
+		return err
+	}
+	r.Id = uint32(v)
+	return nil
+}
+
+// Extract transforms a character resource into a location
+func Extract(r RestModel) (Model, error) {
+	return NewModel(r.WorldId, r.MapId), nil
+}
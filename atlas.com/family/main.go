@@ -1,12 +1,20 @@
 package main
 
 import (
+	"atlas-family/buff"
 	"atlas-family/database"
 	"atlas-family/family"
+	"atlas-family/idempotency"
+	"atlas-family/invitation"
+	"atlas-family/kafka/consumer/character"
 	family2 "atlas-family/kafka/consumer/family"
+	"atlas-family/ledger"
 	"atlas-family/logger"
+	"atlas-family/outbox"
+	"atlas-family/rules"
 	"atlas-family/scheduler"
 	"atlas-family/service"
+	"atlas-family/tombstone"
 	"atlas-family/tracing"
 	"os"
 
@@ -48,8 +56,11 @@ func main() {
 		l.WithError(err).Fatal("Unable to initialize tracer.")
 	}
 
+	// Load the family rules before migrating, as the database constraints are derived from them
+	rulesRegistry := rules.InitRegistry(l)
+
 	// Initialize database connection
-	db := database.Connect(l, database.SetMigrations(family.Migration))
+	db := database.Connect(l, database.SetMigrations(family.Migration, family.FamilyMigration, invitation.Migration, buff.Migration, ledger.Migration, idempotency.Migration, outbox.Migration, tombstone.Migration))
 	if db == nil {
 		l.Fatal("Failed to connect to database")
 	}
@@ -58,6 +69,8 @@ func main() {
 	cmf := consumer.GetManager().AddConsumer(l, tdm.Context(), tdm.WaitGroup())
 	family2.InitConsumers(l)(cmf)(consumerGroupId)
 	family2.InitHandlers(l)(db)(consumer.GetManager().RegisterHandler)
+	character.InitConsumers(l)(cmf)(consumerGroupId)
+	character.InitHandlers(l)(db)(consumer.GetManager().RegisterHandler)
 
 	// Initialize and start reputation reset scheduler
 	reputationResetJob := scheduler.NewReputationResetJob(l, db)
@@ -65,9 +78,34 @@ func main() {
 		l.WithError(err).Fatal("Failed to start reputation reset job")
 	}
 
+	// Initialize and start invitation expiry scheduler
+	invitationExpiryJob := scheduler.NewInvitationExpiryJob(l, db)
+	if err := invitationExpiryJob.Start(tdm.Context()); err != nil {
+		l.WithError(err).Fatal("Failed to start invitation expiry job")
+	}
+
+	// Initialize and start the outbox relay publishing staged events to Kafka
+	outboxRelay := outbox.NewRelay(l, db)
+	if err := outboxRelay.Start(tdm.Context(), tdm.WaitGroup()); err != nil {
+		l.WithError(err).Fatal("Failed to start outbox relay")
+	}
+
+	// Reload the family rules on change, keeping the database constraints in line with them
+	rulesRegistry.OnChange(func(c rules.Config) {
+		if err := family.ApplyRuleConstraints(db)(c.Ceiling()); err != nil {
+			l.WithError(err).Error("Failed to apply family rule constraints")
+		}
+	})
+	if err := rulesRegistry.Start(tdm.Context(), tdm.WaitGroup()); err != nil {
+		l.WithError(err).Fatal("Failed to start family rules reloader")
+	}
+
 	// Setup graceful shutdown for scheduler
 	tdm.TeardownFunc(func() {
 		reputationResetJob.Stop()
+		invitationExpiryJob.Stop()
+		outboxRelay.Stop()
+		rulesRegistry.Stop()
 	})
 
 	server.New(l).
@@ -76,6 +114,10 @@ func main() {
 		SetBasePath(GetServer().GetPrefix()).
 		SetPort(os.Getenv("REST_PORT")).
 		AddRouteInitializer(family.InitResource(GetServer())(db)).
+		AddRouteInitializer(invitation.InitResource(GetServer())(db)).
+		AddRouteInitializer(buff.InitResource(GetServer())(db)).
+		AddRouteInitializer(ledger.InitResource(GetServer())(db)).
+		AddRouteInitializer(idempotency.InitResource(GetServer())(db)).
 		Run()
 
 	tdm.TeardownFunc(tracing.Teardown(l)(tc))
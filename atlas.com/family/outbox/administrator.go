@@ -0,0 +1,130 @@
+package outbox
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Chronicle20/atlas-model/model"
+	tenant "github.com/Chronicle20/atlas-tenant"
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Enqueue stages buffered messages for publication under the tenant of the context, if any. Messages keep their
+// buffered order within each topic.
+func Enqueue(db *gorm.DB, log logrus.FieldLogger) func(ctx context.Context, messages map[string][]kafka.Message) model.Provider[[]Entity] {
+	return func(ctx context.Context, messages map[string][]kafka.Message) model.Provider[[]Entity] {
+		topics := make([]string, 0, len(messages))
+		for t := range messages {
+			topics = append(topics, t)
+		}
+		sort.Strings(topics)
+
+		var entity Entity
+		if t, err := tenant.FromContext(ctx)(); err == nil {
+			entity.TenantId = t.Id()
+			entity.Region = t.Region()
+			entity.MajorVersion = t.MajorVersion()
+			entity.MinorVersion = t.MinorVersion()
+		}
+
+		now := time.Now()
+		entities := make([]Entity, 0)
+		for _, t := range topics {
+			for _, m := range messages[t] {
+				e := entity
+				e.Topic = t
+				e.MessageKey = m.Key
+				e.Value = m.Value
+				e.CreatedAt = now
+				entities = append(entities, e)
+			}
+		}
+		if len(entities) == 0 {
+			return model.FixedProvider(entities)
+		}
+
+		log.WithField("count", len(entities)).Debug("Staging messages in outbox")
+		if err := db.Create(&entities).Error; err != nil {
+			return model.ErrorProvider[[]Entity](err)
+		}
+		return model.FixedProvider(entities)
+	}
+}
+
+// Claim leases staged messages to a relay until the given time, after which another relay may claim them again
+func Claim(db *gorm.DB, log logrus.FieldLogger) func(ids []uint64, claimedUntil time.Time) model.Provider[int64] {
+	return func(ids []uint64, claimedUntil time.Time) model.Provider[int64] {
+		if len(ids) == 0 {
+			return model.FixedProvider[int64](0)
+		}
+		log.WithField("count", len(ids)).Debug("Claiming outbox messages")
+		result := db.Model(&Entity{}).Where("id IN ?", ids).Update("claimed_until", claimedUntil)
+		if result.Error != nil {
+			return model.ErrorProvider[int64](result.Error)
+		}
+		return model.FixedProvider(result.RowsAffected)
+	}
+}
+
+// Release returns claimed messages which could not be published so they are retried on the next pass
+func Release(db *gorm.DB, log logrus.FieldLogger) func(ids []uint64) model.Provider[int64] {
+	return func(ids []uint64) model.Provider[int64] {
+		if len(ids) == 0 {
+			return model.FixedProvider[int64](0)
+		}
+		log.WithField("count", len(ids)).Debug("Releasing outbox messages")
+		result := db.Model(&Entity{}).Where("id IN ?", ids).Update("claimed_until", nil)
+		if result.Error != nil {
+			return model.ErrorProvider[int64](result.Error)
+		}
+		return model.FixedProvider(result.RowsAffected)
+	}
+}
+
+// MarkFailed dead-letters messages which can never be published, keeping them for inspection but out of the relay.
+// Later messages for the same tenant and key are held back until the dead-lettered messages are resolved.
+func MarkFailed(db *gorm.DB, log logrus.FieldLogger) func(ids []uint64, failedAt time.Time, failure string) model.Provider[int64] {
+	return func(ids []uint64, failedAt time.Time, failure string) model.Provider[int64] {
+		if len(ids) == 0 {
+			return model.FixedProvider[int64](0)
+		}
+		log.WithField("count", len(ids)).Warn("Dead-lettering outbox messages")
+		result := db.Model(&Entity{}).Where("id IN ?", ids).Updates(map[string]interface{}{"failed_at": failedAt, "failure": failure, "claimed_until": nil})
+		if result.Error != nil {
+			return model.ErrorProvider[int64](result.Error)
+		}
+		return model.FixedProvider(result.RowsAffected)
+	}
+}
+
+// MarkPublished records the publication of staged messages
+func MarkPublished(db *gorm.DB, log logrus.FieldLogger) func(ids []uint64, publishedAt time.Time) model.Provider[int64] {
+	return func(ids []uint64, publishedAt time.Time) model.Provider[int64] {
+		if len(ids) == 0 {
+			return model.FixedProvider[int64](0)
+		}
+		log.WithField("count", len(ids)).Debug("Marking outbox messages as published")
+		result := db.Model(&Entity{}).Where("id IN ?", ids).Update("published_at", publishedAt)
+		if result.Error != nil {
+			return model.ErrorProvider[int64](result.Error)
+		}
+		return model.FixedProvider(result.RowsAffected)
+	}
+}
+
+// PurgePublished deletes messages published before the given time
+func PurgePublished(db *gorm.DB, log logrus.FieldLogger) func(before time.Time) model.Provider[int64] {
+	return func(before time.Time) model.Provider[int64] {
+		result := db.Where("published_at IS NOT NULL AND published_at < ?", before).Delete(&Entity{})
+		if result.Error != nil {
+			return model.ErrorProvider[int64](result.Error)
+		}
+		if result.RowsAffected > 0 {
+			log.WithField("count", result.RowsAffected).Debug("Purged published outbox messages")
+		}
+		return model.FixedProvider(result.RowsAffected)
+	}
+}
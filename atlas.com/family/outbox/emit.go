@@ -0,0 +1,41 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+
+	"atlas-family/database"
+	"atlas-family/kafka/message"
+	familymsg "atlas-family/kafka/message/family"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Emit applies a change within a database transaction and stages the events it buffers in the outbox as part of the
+// same transaction, so events are published if and only if the change is committed. A failed change stages only its
+// error events, once the change has been rolled back, unless it failed a concurrency check and is expected to be
+// retried.
+func Emit(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) func(f func(tx *gorm.DB, buf *message.Buffer) error) error {
+	return func(f func(tx *gorm.DB, buf *message.Buffer) error) error {
+		buf := message.NewBuffer()
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := f(tx, buf); err != nil {
+				return err
+			}
+			_, err := Enqueue(tx, l)(ctx, buf.GetAll())()
+			return err
+		})
+		if err == nil || errors.Is(err, database.ErrConflict) {
+			return err
+		}
+
+		if ms, ok := buf.GetAll()[familymsg.EnvEventTopicErrors]; ok {
+			if _, serr := Enqueue(db, l)(ctx, map[string][]kafka.Message{familymsg.EnvEventTopicErrors: ms})(); serr != nil {
+				l.WithError(serr).Error("Unable to stage error events in outbox")
+			}
+		}
+		return err
+	}
+}
@@ -0,0 +1,35 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Entity represents a Kafka message staged for publication, written in the same transaction as the change it reports
+type Entity struct {
+	ID           uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	TenantId     uuid.UUID  `gorm:"type:uuid" json:"tenantId"`
+	Region       string     `json:"region"`
+	MajorVersion uint16     `json:"majorVersion"`
+	MinorVersion uint16     `json:"minorVersion"`
+	Topic        string     `gorm:"not null" json:"topic"`
+	MessageKey   []byte     `json:"messageKey"`
+	Value        []byte     `json:"value"`
+	CreatedAt    time.Time  `gorm:"not null" json:"createdAt"`
+	ClaimedUntil *time.Time `json:"claimedUntil"`
+	PublishedAt  *time.Time `gorm:"index" json:"publishedAt"`
+	FailedAt     *time.Time `gorm:"index" json:"failedAt"`
+	Failure      string     `json:"failure"`
+}
+
+// TableName specifies the table name for the Entity
+func (Entity) TableName() string {
+	return "family_outbox"
+}
+
+// Migration creates the family_outbox table
+func Migration(db *gorm.DB) error {
+	return db.AutoMigrate(&Entity{})
+}
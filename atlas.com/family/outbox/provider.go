@@ -0,0 +1,35 @@
+package outbox
+
+import (
+	"time"
+
+	"atlas-family/database"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetPendingProvider retrieves the oldest messages which may be claimed at the given time in staging order, locking them
+// so concurrent relays claim them one at a time. A message is held back while it, or an earlier unpublished message for
+// the same tenant and key, is leased to another relay or dead-lettered.
+func GetPendingProvider(limit int, now time.Time) database.EntityProvider[[]Entity] {
+	return func(db *gorm.DB) model.Provider[[]Entity] {
+		var entities []Entity
+		if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("published_at IS NULL AND failed_at IS NULL AND (claimed_until IS NULL OR claimed_until <= ?)", now).
+			Where(`NOT EXISTS (
+				SELECT 1 FROM family_outbox b
+				WHERE b.published_at IS NULL AND b.id < family_outbox.id
+				AND b.tenant_id = family_outbox.tenant_id
+				AND (b.message_key = family_outbox.message_key OR (b.message_key IS NULL AND family_outbox.message_key IS NULL))
+				AND (b.failed_at IS NOT NULL OR b.claimed_until > ?)
+			)`, now).
+			Order("id").
+			Limit(limit).
+			Find(&entities).Error; err != nil {
+			return model.ErrorProvider[[]Entity](err)
+		}
+		return model.FixedProvider(entities)
+	}
+}
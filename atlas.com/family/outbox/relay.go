@@ -0,0 +1,251 @@
+package outbox
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"atlas-family/kafka/producer"
+
+	"github.com/Chronicle20/atlas-model/model"
+	tenant "github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Relay publishes staged outbox messages to Kafka and marks them as published
+type Relay struct {
+	log       logrus.FieldLogger
+	db        *gorm.DB
+	interval  time.Duration
+	batchSize int
+	lease     time.Duration
+	retention time.Duration
+	producer  func(ctx context.Context) producer.Provider
+}
+
+// NewRelay creates a new outbox relay from environment variables
+func NewRelay(log logrus.FieldLogger, db *gorm.DB) *Relay {
+	// Default to polling twice a second
+	interval := 500 * time.Millisecond
+	batchSize := 100
+	lease := 30 * time.Second
+	retention := 24 * time.Hour
+
+	// Check for custom poll interval
+	if intervalStr, ok := os.LookupEnv("OUTBOX_RELAY_INTERVAL_MILLISECONDS"); ok {
+		if millis, err := strconv.Atoi(intervalStr); err == nil && millis > 0 {
+			interval = time.Duration(millis) * time.Millisecond
+		}
+	}
+
+	// Check for custom batch size
+	if batchStr, ok := os.LookupEnv("OUTBOX_RELAY_BATCH_SIZE"); ok {
+		if size, err := strconv.Atoi(batchStr); err == nil && size > 0 {
+			batchSize = size
+		}
+	}
+
+	// Check for custom claim lease
+	if leaseStr, ok := os.LookupEnv("OUTBOX_RELAY_LEASE_SECONDS"); ok {
+		if seconds, err := strconv.Atoi(leaseStr); err == nil && seconds > 0 {
+			lease = time.Duration(seconds) * time.Second
+		}
+	}
+
+	// Check for custom retention of published messages
+	if retentionStr, ok := os.LookupEnv("OUTBOX_RETENTION_HOURS"); ok {
+		if hours, err := strconv.Atoi(retentionStr); err == nil && hours > 0 {
+			retention = time.Duration(hours) * time.Hour
+		}
+	}
+
+	return &Relay{
+		log:       log,
+		db:        db,
+		interval:  interval,
+		batchSize: batchSize,
+		lease:     lease,
+		retention: retention,
+		producer: func(ctx context.Context) producer.Provider {
+			return producer.ProviderImpl(log)(ctx)
+		},
+	}
+}
+
+// Start begins relaying staged messages until the context is cancelled. The wait group is held while the relay runs
+// so shutdown waits for an in-flight batch.
+func (r *Relay) Start(ctx context.Context, wg *sync.WaitGroup) error {
+	r.log.WithFields(logrus.Fields{
+		"interval":  r.interval.String(),
+		"batchSize": r.batchSize,
+	}).Info("Starting outbox relay")
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.run(ctx)
+	}()
+
+	return nil
+}
+
+// run relays on every tick until the context is cancelled
+func (r *Relay) run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info("Outbox relay stopped")
+			return
+		case <-ticker.C:
+			// Drain full batches without waiting for the next tick
+			for {
+				published, err := r.Publish(ctx)
+				if err != nil {
+					r.log.WithError(err).Error("Failed to relay outbox messages")
+					break
+				}
+				if published < r.batchSize || ctx.Err() != nil {
+					break
+				}
+			}
+			if _, err := PurgePublished(r.db, r.log)(time.Now().Add(-r.retention))(); err != nil {
+				r.log.WithError(err).Error("Failed to purge published outbox messages")
+			}
+		}
+	}
+}
+
+// Stop gracefully stops the outbox relay
+func (r *Relay) Stop() {
+	r.log.Info("Stopping outbox relay")
+}
+
+// stream identifies messages which must be published in staging order relative to one another, those of one tenant
+// with the same key
+type stream struct {
+	tenantId     uuid.UUID
+	region       string
+	majorVersion uint16
+	minorVersion uint16
+	key          string
+}
+
+func streamOf(e Entity) stream {
+	return stream{tenantId: e.TenantId, region: e.Region, majorVersion: e.MajorVersion, minorVersion: e.MinorVersion, key: string(e.MessageKey)}
+}
+
+// Publish relays one batch of staged messages, returning the number published. A batch is claimed and committed
+// before anything is produced, so no row lock is held while waiting on Kafka. Messages are produced per tenant and key
+// in staging order; once one fails, the rest of its stream is released and retried on the next pass, so no message is
+// published ahead of an earlier message for the same key. Messages whose tenant cannot be restored are dead-lettered,
+// and hold back every later message for their key until an operator deletes them or clears their failure.
+func (r *Relay) Publish(ctx context.Context) (int, error) {
+	streams, err := r.claim()
+	if err != nil {
+		return 0, err
+	}
+
+	var published, released, failed []uint64
+	for _, es := range streams {
+		sctx := ctx
+		if s := streamOf(es[0]); s.tenantId != uuid.Nil {
+			t, err := tenant.Create(s.tenantId, s.region, s.majorVersion, s.minorVersion)
+			if err != nil {
+				r.log.WithError(err).WithField("tenantId", s.tenantId).Error("Unable to restore tenant for outbox messages, dead-lettering them")
+				for _, e := range es {
+					failed = append(failed, e.ID)
+				}
+				continue
+			}
+			sctx = tenant.WithContext(ctx, t)
+		}
+
+		n := r.publishStream(sctx, es)
+		for _, e := range es[:n] {
+			published = append(published, e.ID)
+		}
+		for _, e := range es[n:] {
+			released = append(released, e.ID)
+		}
+	}
+
+	now := time.Now()
+	if _, err := MarkFailed(r.db, r.log)(failed, now, "tenant could not be restored")(); err != nil {
+		return 0, err
+	}
+	if _, err := Release(r.db, r.log)(released)(); err != nil {
+		return 0, err
+	}
+	if _, err := MarkPublished(r.db, r.log)(published, now)(); err != nil {
+		return 0, err
+	}
+	return len(published), nil
+}
+
+// claim leases the oldest claimable messages to this relay, grouped into streams in staging order. Streams held back
+// behind a message leased to another relay or dead-lettered are left out by the query, so they cannot fill the batch.
+func (r *Relay) claim() ([][]Entity, error) {
+	var claimed [][]Entity
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		pending, err := GetPendingProvider(r.batchSize, now)(tx)()
+		if err != nil {
+			return err
+		}
+
+		order := make([]stream, 0)
+		streams := make(map[stream][]Entity)
+		for _, e := range pending {
+			s := streamOf(e)
+			if _, ok := streams[s]; !ok {
+				order = append(order, s)
+			}
+			streams[s] = append(streams[s], e)
+		}
+
+		ids := make([]uint64, 0, len(pending))
+		for _, s := range order {
+			es := streams[s]
+			for _, e := range es {
+				ids = append(ids, e.ID)
+			}
+			claimed = append(claimed, es)
+		}
+		_, err = Claim(tx, r.log)(ids, now.Add(r.lease))()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// publishStream produces a stream's messages in order, batching consecutive messages for the same topic, and returns
+// how many were published before the first failure
+func (r *Relay) publishStream(ctx context.Context, es []Entity) int {
+	published := 0
+	for published < len(es) {
+		topic := es[published].Topic
+		end := published
+		ms := make([]kafka.Message, 0)
+		for end < len(es) && es[end].Topic == topic {
+			ms = append(ms, kafka.Message{Key: es[end].MessageKey, Value: es[end].Value})
+			end++
+		}
+
+		if err := r.producer(ctx)(topic)(model.FixedProvider(ms)); err != nil {
+			r.log.WithError(err).WithFields(logrus.Fields{"topic": topic, "key": string(es[published].MessageKey)}).Warn("Unable to publish outbox messages, will retry")
+			return published
+		}
+		published = end
+	}
+	return published
+}
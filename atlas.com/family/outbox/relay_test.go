@@ -0,0 +1,297 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"atlas-family/kafka/message"
+	familymsg "atlas-family/kafka/message/family"
+	"atlas-family/kafka/producer"
+
+	kafkaproducer "github.com/Chronicle20/atlas-kafka/producer"
+	"github.com/Chronicle20/atlas-model/model"
+	tenant "github.com/Chronicle20/atlas-tenant"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDatabase creates an isolated in-memory SQLite database with the outbox schema
+func newTestDatabase(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+uuid.NewString()+"?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	if err := Migration(db); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	return db
+}
+
+// newTestRelay creates a relay which hands messages to the given producer instead of Kafka
+func newTestRelay(db *gorm.DB, produce func(topic string, ms []kafka.Message) error) *Relay {
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+	return &Relay{
+		log:       l,
+		db:        db,
+		batchSize: 100,
+		lease:     time.Minute,
+		producer: func(ctx context.Context) producer.Provider {
+			return func(token string) kafkaproducer.MessageProducer {
+				return func(provider model.Provider[[]kafka.Message]) error {
+					ms, err := provider()
+					if err != nil {
+						return err
+					}
+					return produce(token, ms)
+				}
+			}
+		},
+	}
+}
+
+func TestRelay_PublishesInOrderAndRetriesFailedTopics(t *testing.T) {
+	db := newTestDatabase(t)
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+	tm, err := tenant.Create(uuid.New(), "GMS", 83, 1)
+	if err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+	ctx := tenant.WithContext(context.Background(), tm)
+
+	staged := map[string][]kafka.Message{
+		"STATUS": {{Key: []byte("1"), Value: []byte("a")}, {Key: []byte("1"), Value: []byte("b")}},
+		"REP":    {{Key: []byte("2"), Value: []byte("c")}},
+	}
+	if _, err := Enqueue(db, l)(ctx, staged)(); err != nil {
+		t.Fatalf("Failed to stage messages: %v", err)
+	}
+
+	published := make(map[string][]string)
+	failRep := true
+	relay := newTestRelay(db, func(topic string, ms []kafka.Message) error {
+		if topic == "REP" && failRep {
+			return errors.New("broker unavailable")
+		}
+		for _, m := range ms {
+			published[topic] = append(published[topic], string(m.Value))
+		}
+		return nil
+	})
+
+	count, err := relay.Publish(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to publish: %v", err)
+	}
+	if count != 2 || len(published["STATUS"]) != 2 || published["STATUS"][0] != "a" || published["STATUS"][1] != "b" {
+		t.Fatalf("Expected STATUS messages published in order, got %d published: %v", count, published)
+	}
+	if len(published["REP"]) != 0 {
+		t.Fatalf("Expected REP messages to remain staged, got %v", published["REP"])
+	}
+
+	failRep = false
+	if count, err = relay.Publish(context.Background()); err != nil || count != 1 {
+		t.Fatalf("Expected the failed message to be retried, got %d published: %v", count, err)
+	}
+	if len(published["REP"]) != 1 || len(published["STATUS"]) != 2 {
+		t.Errorf("Expected each message to be published exactly once, got %v", published)
+	}
+
+	var pending int64
+	if err := db.Model(&Entity{}).Where("published_at IS NULL").Count(&pending).Error; err != nil {
+		t.Fatalf("Failed to count pending messages: %v", err)
+	}
+	if pending != 0 {
+		t.Errorf("Expected no pending messages, got %d", pending)
+	}
+}
+
+func TestRelay_HoldsBackLaterMessagesForTheSameKey(t *testing.T) {
+	db := newTestDatabase(t)
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+
+	// Topics are staged in name order, so the REP message for key 1 precedes its STATUS messages
+	staged := map[string][]kafka.Message{
+		"STATUS": {{Key: []byte("1"), Value: []byte("a")}, {Key: []byte("1"), Value: []byte("b")}, {Key: []byte("2"), Value: []byte("d")}},
+		"REP":    {{Key: []byte("1"), Value: []byte("c")}},
+	}
+	if _, err := Enqueue(db, l)(context.Background(), staged)(); err != nil {
+		t.Fatalf("Failed to stage messages: %v", err)
+	}
+
+	var published []string
+	failRep := true
+	var relay *Relay
+	relay = newTestRelay(db, func(topic string, ms []kafka.Message) error {
+		// The claim is committed before anything is produced
+		var claimed int64
+		if err := relay.db.Model(&Entity{}).Where("claimed_until IS NOT NULL").Count(&claimed).Error; err != nil || claimed == 0 {
+			t.Errorf("Expected messages to be claimed while publishing, got %d: %v", claimed, err)
+		}
+		if topic == "REP" && failRep {
+			return errors.New("broker unavailable")
+		}
+		for _, m := range ms {
+			published = append(published, string(m.Value))
+		}
+		return nil
+	})
+
+	if count, err := relay.Publish(context.Background()); err != nil || count != 1 {
+		t.Fatalf("Expected only the other key to be published, got %d published: %v", count, err)
+	}
+	if len(published) != 1 || published[0] != "d" {
+		t.Fatalf("Expected key 1 to be held back behind its failed message, got %v", published)
+	}
+
+	failRep = false
+	if count, err := relay.Publish(context.Background()); err != nil || count != 3 {
+		t.Fatalf("Expected the released messages to be retried, got %d published: %v", count, err)
+	}
+	if len(published) != 4 || published[1] != "c" || published[2] != "a" || published[3] != "b" {
+		t.Errorf("Expected key 1 to be published in staging order, got %v", published)
+	}
+}
+
+func TestRelay_SkipsStreamsClaimedByAnotherRelay(t *testing.T) {
+	db := newTestDatabase(t)
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+
+	staged := map[string][]kafka.Message{
+		"STATUS": {{Key: []byte("1"), Value: []byte("a")}, {Key: []byte("2"), Value: []byte("b")}, {Key: []byte("1"), Value: []byte("c")}},
+	}
+	entities, err := Enqueue(db, l)(context.Background(), staged)()
+	if err != nil {
+		t.Fatalf("Failed to stage messages: %v", err)
+	}
+	if _, err := Claim(db, l)([]uint64{entities[0].ID}, time.Now().Add(time.Minute))(); err != nil {
+		t.Fatalf("Failed to claim message: %v", err)
+	}
+
+	var published []string
+	relay := newTestRelay(db, func(topic string, ms []kafka.Message) error {
+		for _, m := range ms {
+			published = append(published, string(m.Value))
+		}
+		return nil
+	})
+	if count, err := relay.Publish(context.Background()); err != nil || count != 1 {
+		t.Fatalf("Expected only the unclaimed key to be published, got %d published: %v", count, err)
+	}
+	if len(published) != 1 || published[0] != "b" {
+		t.Errorf("Expected later messages for a claimed key to be held back, got %v", published)
+	}
+}
+
+func TestRelay_LeasedMessagesDoNotFillTheBatch(t *testing.T) {
+	db := newTestDatabase(t)
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+
+	staged := map[string][]kafka.Message{
+		"STATUS": {{Key: []byte("1"), Value: []byte("a")}, {Key: []byte("2"), Value: []byte("b")}, {Key: []byte("3"), Value: []byte("c")}},
+	}
+	entities, err := Enqueue(db, l)(context.Background(), staged)()
+	if err != nil {
+		t.Fatalf("Failed to stage messages: %v", err)
+	}
+	if _, err := Claim(db, l)([]uint64{entities[0].ID, entities[1].ID}, time.Now().Add(time.Minute))(); err != nil {
+		t.Fatalf("Failed to claim messages: %v", err)
+	}
+
+	var published []string
+	relay := newTestRelay(db, func(topic string, ms []kafka.Message) error {
+		for _, m := range ms {
+			published = append(published, string(m.Value))
+		}
+		return nil
+	})
+	relay.batchSize = 2
+	if count, err := relay.Publish(context.Background()); err != nil || count != 1 {
+		t.Fatalf("Expected the unleased message to be published, got %d published: %v", count, err)
+	}
+	if len(published) != 1 || published[0] != "c" {
+		t.Errorf("Expected only the unleased message to be published, got %v", published)
+	}
+}
+
+func TestRelay_DeadLetterHoldsBackItsKey(t *testing.T) {
+	db := newTestDatabase(t)
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+
+	staged := map[string][]kafka.Message{
+		"STATUS": {{Key: []byte("1"), Value: []byte("a")}, {Key: []byte("1"), Value: []byte("b")}, {Key: []byte("2"), Value: []byte("c")}},
+	}
+	entities, err := Enqueue(db, l)(context.Background(), staged)()
+	if err != nil {
+		t.Fatalf("Failed to stage messages: %v", err)
+	}
+	if _, err := MarkFailed(db, l)([]uint64{entities[0].ID}, time.Now(), "tenant could not be restored")(); err != nil {
+		t.Fatalf("Failed to dead-letter message: %v", err)
+	}
+
+	var published []string
+	relay := newTestRelay(db, func(topic string, ms []kafka.Message) error {
+		for _, m := range ms {
+			published = append(published, string(m.Value))
+		}
+		return nil
+	})
+	if count, err := relay.Publish(context.Background()); err != nil || count != 1 {
+		t.Fatalf("Expected only the other key to be published, got %d published: %v", count, err)
+	}
+	if len(published) != 1 || published[0] != "c" {
+		t.Fatalf("Expected key 1 to be held back behind its dead-lettered message, got %v", published)
+	}
+
+	// Resolving the dead letter releases the rest of the stream
+	if err := db.Delete(&Entity{}, entities[0].ID).Error; err != nil {
+		t.Fatalf("Failed to delete dead-lettered message: %v", err)
+	}
+	if count, err := relay.Publish(context.Background()); err != nil || count != 1 {
+		t.Fatalf("Expected the held back message to be published, got %d published: %v", count, err)
+	}
+	if len(published) != 2 || published[1] != "b" {
+		t.Errorf("Expected the held back message to be published, got %v", published)
+	}
+}
+
+func TestEmit_StagesEventsOnlyForCommittedChanges(t *testing.T) {
+	db := newTestDatabase(t)
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+	ctx := context.Background()
+	rejected := errors.New("rejected")
+
+	err := Emit(l, ctx, db)(func(tx *gorm.DB, buf *message.Buffer) error {
+		_ = buf.Put(familymsg.EnvEventTopicStatus, model.FixedProvider([]kafka.Message{{Key: []byte("1"), Value: []byte("LINK_CREATED")}}))
+		_ = buf.Put(familymsg.EnvEventTopicErrors, model.FixedProvider([]kafka.Message{{Key: []byte("1"), Value: []byte("LINK_ERROR")}}))
+		return rejected
+	})
+	if !errors.Is(err, rejected) {
+		t.Fatalf("Expected the change error, got %v", err)
+	}
+
+	var entities []Entity
+	if err := db.Order("id").Find(&entities).Error; err != nil {
+		t.Fatalf("Failed to load outbox: %v", err)
+	}
+	if len(entities) != 1 || entities[0].Topic != familymsg.EnvEventTopicErrors {
+		t.Fatalf("Expected only the error event to be staged, got %+v", entities)
+	}
+	if entities[0].TenantId != uuid.Nil {
+		t.Errorf("Expected no tenant outside a tenant context, got %s", entities[0].TenantId)
+	}
+}
@@ -2,6 +2,7 @@ package rest
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"strconv"
@@ -97,3 +98,61 @@ func ParseCharacterId(l logrus.FieldLogger, next CharacterIdHandler) http.Handle
 		next(uint32(characterId))(w, r)
 	}
 }
+
+type JuniorIdHandler func(juniorId uint32) http.HandlerFunc
+
+func ParseJuniorId(l logrus.FieldLogger, next JuniorIdHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		juniorId, err := strconv.Atoi(mux.Vars(r)["juniorId"])
+		if err != nil {
+			l.WithError(err).Errorf("Unable to properly parse juniorId from path.")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		next(uint32(juniorId))(w, r)
+	}
+}
+
+type InvitationIdHandler func(invitationId uint32) http.HandlerFunc
+
+func ParseInvitationId(l logrus.FieldLogger, next InvitationIdHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		invitationId, err := strconv.Atoi(mux.Vars(r)["invitationId"])
+		if err != nil {
+			l.WithError(err).Errorf("Unable to properly parse invitationId from path.")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		next(uint32(invitationId))(w, r)
+	}
+}
+
+type FamilyIdHandler func(familyId uint32) http.HandlerFunc
+
+func ParseFamilyId(l logrus.FieldLogger, next FamilyIdHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		familyId, err := strconv.Atoi(mux.Vars(r)["familyId"])
+		if err != nil {
+			l.WithError(err).Errorf("Unable to properly parse familyId from path.")
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		next(uint32(familyId))(w, r)
+	}
+}
+
+// WriteErrorResponse writes an error response in JSON format
+func WriteErrorResponse(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	errorResponse := map[string]interface{}{
+		"error": map[string]interface{}{
+			"status": statusCode,
+			"title":  http.StatusText(statusCode),
+			"detail": message,
+		},
+	}
+
+	json.NewEncoder(w).Encode(errorResponse)
+}
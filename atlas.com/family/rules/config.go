@@ -0,0 +1,116 @@
+package rules
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RuleConfig is the file representation of a set of rules. Omitted values are inherited from the default rules.
+type RuleConfig struct {
+	MaxJuniors           *uint32 `json:"maxJuniors,omitempty"`
+	MaxLevelDifference   *uint16 `json:"maxLevelDifference,omitempty"`
+	DailyRepCap          *uint32 `json:"dailyRepCap,omitempty"`
+	LeaveRepCost         *uint32 `json:"leaveRepCost,omitempty"`
+	ExpelRepCost         *uint32 `json:"expelRepCost,omitempty"`
+	LeaveCooldownSeconds *uint32 `json:"leaveCooldownSeconds,omitempty"`
+	ExpelCooldownSeconds *uint32 `json:"expelCooldownSeconds,omitempty"`
+	BreakCooldownSeconds *uint32 `json:"breakCooldownSeconds,omitempty"`
+}
+
+// apply overlays the configured values onto base
+func (rc RuleConfig) apply(base Model) (Model, error) {
+	b := base.Builder()
+	if rc.MaxJuniors != nil {
+		b.SetMaxJuniors(*rc.MaxJuniors)
+	}
+	if rc.MaxLevelDifference != nil {
+		b.SetMaxLevelDifference(*rc.MaxLevelDifference)
+	}
+	if rc.DailyRepCap != nil {
+		b.SetDailyRepCap(*rc.DailyRepCap)
+	}
+	if rc.LeaveRepCost != nil {
+		b.SetLeaveRepCost(*rc.LeaveRepCost)
+	}
+	if rc.ExpelRepCost != nil {
+		b.SetExpelRepCost(*rc.ExpelRepCost)
+	}
+	if rc.LeaveCooldownSeconds != nil {
+		b.SetLeaveCooldown(time.Duration(*rc.LeaveCooldownSeconds) * time.Second)
+	}
+	if rc.ExpelCooldownSeconds != nil {
+		b.SetExpelCooldown(time.Duration(*rc.ExpelCooldownSeconds) * time.Second)
+	}
+	if rc.BreakCooldownSeconds != nil {
+		b.SetBreakCooldown(time.Duration(*rc.BreakCooldownSeconds) * time.Second)
+	}
+	return b.Build()
+}
+
+// Config holds the resolved default rules and per-tenant overrides keyed by tenant id
+type Config struct {
+	defaults Model
+	tenants  map[string]Model
+}
+
+// DefaultConfig applies the default rules to every tenant
+func DefaultConfig() Config {
+	return Config{defaults: Default(), tenants: map[string]Model{}}
+}
+
+// ParseConfig parses and validates a JSON rules configuration. Tenant rules inherit unset values from the configured
+// default, which in turn inherits from the built-in defaults.
+func ParseConfig(data []byte) (Config, error) {
+	var file struct {
+		Default RuleConfig            `json:"default"`
+		Tenants map[string]RuleConfig `json:"tenants"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Config{}, err
+	}
+
+	defaults, err := file.Default.apply(Default())
+	if err != nil {
+		return Config{}, err
+	}
+	tenants := make(map[string]Model, len(file.Tenants))
+	for tenantId, rc := range file.Tenants {
+		id, err := uuid.Parse(tenantId)
+		if err != nil {
+			return Config{}, err
+		}
+		m, err := rc.apply(defaults)
+		if err != nil {
+			return Config{}, err
+		}
+		tenants[id.String()] = m
+	}
+	return Config{defaults: defaults, tenants: tenants}, nil
+}
+
+// Rules returns the rules in force for the tenant
+func (c Config) Rules(tenantId uuid.UUID) Model {
+	if m, ok := c.tenants[tenantId.String()]; ok {
+		return m
+	}
+	return c.defaults
+}
+
+// Ceiling returns the most permissive value of each limit across all tenants, for constraints shared by every tenant
+func (c Config) Ceiling() Model {
+	ceiling := c.defaults
+	for _, m := range c.tenants {
+		if m.maxJuniors > ceiling.maxJuniors {
+			ceiling.maxJuniors = m.maxJuniors
+		}
+		if m.maxLevelDifference > ceiling.maxLevelDifference {
+			ceiling.maxLevelDifference = m.maxLevelDifference
+		}
+		if m.dailyRepCap > ceiling.dailyRepCap {
+			ceiling.dailyRepCap = m.dailyRepCap
+		}
+	}
+	return ceiling
+}
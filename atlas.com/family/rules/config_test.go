@@ -0,0 +1,80 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestParseConfig(t *testing.T) {
+	tenantId := uuid.New()
+	data := []byte(`{
+		"default": {"dailyRepCap": 4000},
+		"tenants": {"` + tenantId.String() + `": {"maxJuniors": 3, "maxLevelDifference": 10, "expelRepCost": 200, "expelCooldownSeconds": 60, "breakCooldownSeconds": 0}}
+	}`)
+
+	c, err := ParseConfig(data)
+	if err != nil {
+		t.Fatalf("Failed to parse rules: %v", err)
+	}
+
+	r := c.Rules(tenantId)
+	if r.MaxJuniors() != 3 || r.MaxLevelDifference() != 10 || r.DailyRepCap() != 4000 {
+		t.Errorf("Unexpected tenant rules: juniors %d, level difference %d, daily cap %d", r.MaxJuniors(), r.MaxLevelDifference(), r.DailyRepCap())
+	}
+
+	if r.ExpelRepCost() != 200 || r.ExpelCooldown() != time.Minute || r.LeaveCooldown() != DefaultLeaveCooldown || r.BreakCooldown() != 0 {
+		t.Errorf("Unexpected tenant costs: expel cost %d, expel cooldown %s, leave cooldown %s, break cooldown %s", r.ExpelRepCost(), r.ExpelCooldown(), r.LeaveCooldown(), r.BreakCooldown())
+	}
+
+	d := c.Rules(uuid.New())
+	if d.MaxJuniors() != DefaultMaxJuniors || d.MaxLevelDifference() != DefaultMaxLevelDifference || d.DailyRepCap() != 4000 {
+		t.Errorf("Unexpected default rules: juniors %d, level difference %d, daily cap %d", d.MaxJuniors(), d.MaxLevelDifference(), d.DailyRepCap())
+	}
+
+	ceiling := c.Ceiling()
+	if ceiling.MaxJuniors() != 3 || ceiling.MaxLevelDifference() != DefaultMaxLevelDifference || ceiling.DailyRepCap() != 4000 {
+		t.Errorf("Unexpected ceiling: juniors %d, level difference %d, daily cap %d", ceiling.MaxJuniors(), ceiling.MaxLevelDifference(), ceiling.DailyRepCap())
+	}
+}
+
+func TestParseConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"Malformed JSON", `{"default": {`},
+		{"Zero juniors", `{"default": {"maxJuniors": 0}}`},
+		{"Zero daily cap", `{"tenants": {"` + uuid.NewString() + `": {"dailyRepCap": 0}}}`},
+		{"Invalid tenant", `{"tenants": {"not-a-tenant": {"maxJuniors": 3}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseConfig([]byte(tt.data)); err == nil {
+				t.Errorf("Expected error for %s", tt.data)
+			}
+		})
+	}
+}
+
+func TestModel_Limits(t *testing.T) {
+	r, err := NewBuilder().SetMaxJuniors(3).SetMaxLevelDifference(5).SetDailyRepCap(100).Build()
+	if err != nil {
+		t.Fatalf("Failed to build rules: %v", err)
+	}
+
+	if !r.CanAddJunior(2) || r.CanAddJunior(3) {
+		t.Error("Expected a third junior but not a fourth to be allowed")
+	}
+	if !r.WithinLevelDifference(50, 45) || r.WithinLevelDifference(45, 51) {
+		t.Error("Expected level difference to be bounded by 5")
+	}
+	if r.RemainingDailyRep(60) != 40 || r.RemainingDailyRep(150) != 0 {
+		t.Errorf("Unexpected remaining daily rep: %d, %d", r.RemainingDailyRep(60), r.RemainingDailyRep(150))
+	}
+	if !r.CanReceiveRep(60, 40) || r.CanReceiveRep(60, 41) {
+		t.Error("Expected daily rep to be bounded by 100")
+	}
+}
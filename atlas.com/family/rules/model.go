@@ -0,0 +1,217 @@
+package rules
+
+import (
+	"errors"
+	"time"
+)
+
+// Default limits used for every tenant without configured rules
+const (
+	DefaultMaxJuniors         = uint32(2)
+	DefaultMaxLevelDifference = uint16(20)
+	DefaultDailyRepCap        = uint32(5000)
+	DefaultLeaveRepCost       = uint32(0)
+	DefaultExpelRepCost       = uint32(0)
+	DefaultLeaveCooldown      = 24 * time.Hour
+	DefaultExpelCooldown      = 24 * time.Hour
+	DefaultBreakCooldown      = 24 * time.Hour
+)
+
+var (
+	ErrInvalidMaxJuniors  = errors.New("max juniors must be at least 1")
+	ErrInvalidDailyRepCap = errors.New("daily rep cap must be at least 1")
+)
+
+// Model represents the immutable family limits in force for a tenant
+type Model struct {
+	maxJuniors         uint32
+	maxLevelDifference uint16
+	dailyRepCap        uint32
+	leaveRepCost       uint32
+	expelRepCost       uint32
+	leaveCooldown      time.Duration
+	expelCooldown      time.Duration
+	breakCooldown      time.Duration
+}
+
+// Default returns the rules applied when none are configured
+func Default() Model {
+	return Model{
+		maxJuniors:         DefaultMaxJuniors,
+		maxLevelDifference: DefaultMaxLevelDifference,
+		dailyRepCap:        DefaultDailyRepCap,
+		leaveRepCost:       DefaultLeaveRepCost,
+		expelRepCost:       DefaultExpelRepCost,
+		leaveCooldown:      DefaultLeaveCooldown,
+		expelCooldown:      DefaultExpelCooldown,
+		breakCooldown:      DefaultBreakCooldown,
+	}
+}
+
+// MaxJuniors returns how many juniors a senior may have
+func (m Model) MaxJuniors() uint32 {
+	return m.maxJuniors
+}
+
+// MaxLevelDifference returns the largest level gap allowed between a senior and a junior
+func (m Model) MaxLevelDifference() uint16 {
+	return m.maxLevelDifference
+}
+
+// DailyRepCap returns how much rep a member may receive per day
+func (m Model) DailyRepCap() uint32 {
+	return m.dailyRepCap
+}
+
+// LeaveRepCost returns the rep a junior pays to leave their senior
+func (m Model) LeaveRepCost() uint32 {
+	return m.leaveRepCost
+}
+
+// ExpelRepCost returns the rep a senior pays to expel one of their juniors
+func (m Model) ExpelRepCost() uint32 {
+	return m.expelRepCost
+}
+
+// LeaveCooldown returns how long a junior who left their senior must wait before joining a family again
+func (m Model) LeaveCooldown() time.Duration {
+	return m.leaveCooldown
+}
+
+// ExpelCooldown returns how long an expelled junior must wait before joining a family again
+func (m Model) ExpelCooldown() time.Duration {
+	return m.expelCooldown
+}
+
+// BreakCooldown returns how long a junior whose link was broken must wait before joining a family again
+func (m Model) BreakCooldown() time.Duration {
+	return m.breakCooldown
+}
+
+// CanAddJunior returns true if a senior with juniorCount juniors may take another
+func (m Model) CanAddJunior(juniorCount int) bool {
+	return uint32(juniorCount) < m.maxJuniors
+}
+
+// WithinLevelDifference returns true if the two levels are close enough to be linked
+func (m Model) WithinLevelDifference(seniorLevel uint16, juniorLevel uint16) bool {
+	diff := int(seniorLevel) - int(juniorLevel)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= int(m.maxLevelDifference)
+}
+
+// RemainingDailyRep returns how much more rep a member with the given daily rep may receive today
+func (m Model) RemainingDailyRep(dailyRep uint32) uint32 {
+	if dailyRep >= m.dailyRepCap {
+		return 0
+	}
+	return m.dailyRepCap - dailyRep
+}
+
+// CanReceiveRep returns true if amount may be added to the daily rep without exceeding the cap
+func (m Model) CanReceiveRep(dailyRep uint32, amount uint32) bool {
+	return uint64(dailyRep)+uint64(amount) <= uint64(m.dailyRepCap)
+}
+
+// Builder constructs immutable rules, starting from the defaults
+type Builder struct {
+	maxJuniors         uint32
+	maxLevelDifference uint16
+	dailyRepCap        uint32
+	leaveRepCost       uint32
+	expelRepCost       uint32
+	leaveCooldown      time.Duration
+	expelCooldown      time.Duration
+	breakCooldown      time.Duration
+}
+
+// NewBuilder creates a builder initialised with the default rules
+func NewBuilder() *Builder {
+	d := Default()
+	return &Builder{
+		maxJuniors:         d.maxJuniors,
+		maxLevelDifference: d.maxLevelDifference,
+		dailyRepCap:        d.dailyRepCap,
+		leaveRepCost:       d.leaveRepCost,
+		expelRepCost:       d.expelRepCost,
+		leaveCooldown:      d.leaveCooldown,
+		expelCooldown:      d.expelCooldown,
+		breakCooldown:      d.breakCooldown,
+	}
+}
+
+// Builder returns a new builder for modification
+func (m Model) Builder() *Builder {
+	return &Builder{
+		maxJuniors:         m.maxJuniors,
+		maxLevelDifference: m.maxLevelDifference,
+		dailyRepCap:        m.dailyRepCap,
+		leaveRepCost:       m.leaveRepCost,
+		expelRepCost:       m.expelRepCost,
+		leaveCooldown:      m.leaveCooldown,
+		expelCooldown:      m.expelCooldown,
+		breakCooldown:      m.breakCooldown,
+	}
+}
+
+func (b *Builder) SetMaxJuniors(maxJuniors uint32) *Builder {
+	b.maxJuniors = maxJuniors
+	return b
+}
+
+func (b *Builder) SetMaxLevelDifference(maxLevelDifference uint16) *Builder {
+	b.maxLevelDifference = maxLevelDifference
+	return b
+}
+
+func (b *Builder) SetDailyRepCap(dailyRepCap uint32) *Builder {
+	b.dailyRepCap = dailyRepCap
+	return b
+}
+
+func (b *Builder) SetLeaveRepCost(leaveRepCost uint32) *Builder {
+	b.leaveRepCost = leaveRepCost
+	return b
+}
+
+func (b *Builder) SetExpelRepCost(expelRepCost uint32) *Builder {
+	b.expelRepCost = expelRepCost
+	return b
+}
+
+func (b *Builder) SetLeaveCooldown(leaveCooldown time.Duration) *Builder {
+	b.leaveCooldown = leaveCooldown
+	return b
+}
+
+func (b *Builder) SetExpelCooldown(expelCooldown time.Duration) *Builder {
+	b.expelCooldown = expelCooldown
+	return b
+}
+
+func (b *Builder) SetBreakCooldown(breakCooldown time.Duration) *Builder {
+	b.breakCooldown = breakCooldown
+	return b
+}
+
+// Build validates and constructs the final immutable rules
+func (b *Builder) Build() (Model, error) {
+	if b.maxJuniors == 0 {
+		return Model{}, ErrInvalidMaxJuniors
+	}
+	if b.dailyRepCap == 0 {
+		return Model{}, ErrInvalidDailyRepCap
+	}
+	return Model{
+		maxJuniors:         b.maxJuniors,
+		maxLevelDifference: b.maxLevelDifference,
+		dailyRepCap:        b.dailyRepCap,
+		leaveRepCost:       b.leaveRepCost,
+		expelRepCost:       b.expelRepCost,
+		leaveCooldown:      b.leaveCooldown,
+		expelCooldown:      b.expelCooldown,
+		breakCooldown:      b.breakCooldown,
+	}, nil
+}
@@ -0,0 +1,188 @@
+package rules
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// EnvRulesFile configures the path of a JSON file describing the family rules per tenant
+const EnvRulesFile = "FAMILY_RULES_FILE"
+
+// EnvReloadSeconds configures how often the rules configuration is checked for changes
+const EnvReloadSeconds = "FAMILY_RULES_RELOAD_SECONDS"
+
+// DefaultReloadInterval is used when no reload interval is configured
+const DefaultReloadInterval = 30 * time.Second
+
+// Registry holds the current rules configuration and reloads it from its source while running
+type Registry struct {
+	log       logrus.FieldLogger
+	source    Source
+	interval  time.Duration
+	mu        sync.RWMutex
+	data      []byte
+	config    Config
+	listeners []func(Config)
+}
+
+// NewRegistry creates a registry for the source and performs the initial load. The default rules apply until a valid
+// configuration is loaded.
+func NewRegistry(l logrus.FieldLogger, source Source) *Registry {
+	interval := DefaultReloadInterval
+	if value, ok := os.LookupEnv(EnvReloadSeconds); ok {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			interval = time.Duration(seconds) * time.Second
+		}
+	}
+
+	r := &Registry{
+		log:      l,
+		source:   source,
+		interval: interval,
+		config:   DefaultConfig(),
+	}
+	if _, err := r.Reload(); err != nil {
+		l.WithError(err).Error("Unable to load family rules, using defaults")
+	}
+	return r
+}
+
+// Rules returns the rules currently in force for the tenant
+func (r *Registry) Rules(tenantId uuid.UUID) Model {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.config.Rules(tenantId)
+}
+
+// Config returns the current rules configuration
+func (r *Registry) Config() Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.config
+}
+
+// OnChange registers a function called with the new configuration after every successful reload that changed it
+func (r *Registry) OnChange(f func(Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, f)
+}
+
+// Reload reads the source and applies the configuration if it changed, returning whether it did. An invalid
+// configuration is rejected and the current rules stay in force.
+func (r *Registry) Reload() (bool, error) {
+	data, err := r.source.Load()
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := r.data != nil && bytes.Equal(r.data, data)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	c := DefaultConfig()
+	if len(bytes.TrimSpace(data)) > 0 {
+		if c, err = ParseConfig(data); err != nil {
+			return false, err
+		}
+	}
+
+	r.mu.Lock()
+	r.data = append([]byte{}, data...)
+	r.config = c
+	listeners := append([]func(Config){}, r.listeners...)
+	r.mu.Unlock()
+
+	r.log.Info("Family rules configuration loaded")
+	for _, f := range listeners {
+		f(c)
+	}
+	return true, nil
+}
+
+// Start begins reloading the configuration until the context is cancelled
+func (r *Registry) Start(ctx context.Context, wg *sync.WaitGroup) error {
+	r.log.WithField("interval", r.interval.String()).Info("Starting family rules reloader")
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				r.log.Info("Family rules reloader stopped")
+				return
+			case <-ticker.C:
+				if _, err := r.Reload(); err != nil {
+					r.log.WithError(err).Error("Failed to reload family rules, keeping current rules")
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop gracefully stops the reloader
+func (r *Registry) Stop() {
+	r.log.Info("Stopping family rules reloader")
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   *Registry
+)
+
+// InitRegistry creates the registry from EnvRulesFile and installs it as the registry used by GetRegistry. Without a
+// configured file the default rules apply to every tenant.
+func InitRegistry(l logrus.FieldLogger) *Registry {
+	var source Source = NewMemorySource(nil)
+	if path, ok := os.LookupEnv(EnvRulesFile); ok {
+		source = NewFileSource(path)
+	}
+	r := NewRegistry(l, source)
+	SetRegistry(r)
+	return r
+}
+
+// SetRegistry installs the registry used by GetRegistry
+func SetRegistry(r *Registry) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = r
+}
+
+// GetRegistry returns the installed registry, creating one serving the default rules if none was installed
+func GetRegistry() *Registry {
+	registryMu.RLock()
+	r := registry
+	registryMu.RUnlock()
+	if r != nil {
+		return r
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if registry == nil {
+		registry = NewRegistry(logrus.StandardLogger(), NewMemorySource(nil))
+	}
+	return registry
+}
+
+// For returns the rules currently in force for the tenant
+func For(tenantId uuid.UUID) Model {
+	return GetRegistry().Rules(tenantId)
+}
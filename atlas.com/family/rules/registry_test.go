@@ -0,0 +1,77 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+func newTestLogger() logrus.FieldLogger {
+	l := logrus.New()
+	l.SetLevel(logrus.PanicLevel)
+	return l
+}
+
+func TestRegistry_ReloadsChangedConfiguration(t *testing.T) {
+	tenantId := uuid.New()
+	source := NewMemorySource(nil)
+	r := NewRegistry(newTestLogger(), source)
+
+	if r.Rules(tenantId).MaxJuniors() != DefaultMaxJuniors {
+		t.Fatalf("Expected default rules before any configuration")
+	}
+
+	var notified []Config
+	r.OnChange(func(c Config) {
+		notified = append(notified, c)
+	})
+
+	source.Set([]byte(`{"tenants": {"` + tenantId.String() + `": {"maxJuniors": 4}}}`))
+	if changed, err := r.Reload(); err != nil || !changed {
+		t.Fatalf("Expected configuration to be reloaded, got %v, %v", changed, err)
+	}
+	if r.Rules(tenantId).MaxJuniors() != 4 {
+		t.Errorf("Expected tenant rules to apply after reload, got %d juniors", r.Rules(tenantId).MaxJuniors())
+	}
+	if len(notified) != 1 || notified[0].Ceiling().MaxJuniors() != 4 {
+		t.Errorf("Expected a single change notification, got %d", len(notified))
+	}
+
+	if changed, err := r.Reload(); err != nil || changed {
+		t.Errorf("Expected unchanged configuration to be skipped, got %v, %v", changed, err)
+	}
+
+	// An invalid configuration leaves the current rules in force
+	source.Set([]byte(`{"default": {"maxJuniors": 0}}`))
+	if _, err := r.Reload(); err == nil {
+		t.Error("Expected invalid configuration to be rejected")
+	}
+	if r.Rules(tenantId).MaxJuniors() != 4 || len(notified) != 1 {
+		t.Errorf("Expected rules to be unchanged by an invalid configuration")
+	}
+}
+
+func TestRegistry_FileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(`{"default": {"dailyRepCap": 3000}}`), 0o600); err != nil {
+		t.Fatalf("Failed to write rules: %v", err)
+	}
+
+	r := NewRegistry(newTestLogger(), NewFileSource(path))
+	if r.Rules(uuid.New()).DailyRepCap() != 3000 {
+		t.Fatalf("Expected rules to be loaded from file")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"default": {"dailyRepCap": 2500}}`), 0o600); err != nil {
+		t.Fatalf("Failed to write rules: %v", err)
+	}
+	if _, err := r.Reload(); err != nil {
+		t.Fatalf("Failed to reload rules: %v", err)
+	}
+	if r.Rules(uuid.New()).DailyRepCap() != 2500 {
+		t.Errorf("Expected edited file to be picked up on reload")
+	}
+}
@@ -0,0 +1,50 @@
+package rules
+
+import (
+	"os"
+	"sync"
+)
+
+// Source supplies the raw JSON rules configuration
+type Source interface {
+	Load() ([]byte, error)
+}
+
+// FileSource reads the rules configuration from a local file
+type FileSource struct {
+	path string
+}
+
+// NewFileSource creates a source reading the file at path
+func NewFileSource(path string) FileSource {
+	return FileSource{path: path}
+}
+
+func (s FileSource) Load() ([]byte, error) {
+	return os.ReadFile(s.path)
+}
+
+// MemorySource holds the rules configuration in memory. It stands in for a configuration service, which pushes new
+// documents with Set.
+type MemorySource struct {
+	mu   sync.RWMutex
+	data []byte
+}
+
+// NewMemorySource creates a source holding data
+func NewMemorySource(data []byte) *MemorySource {
+	return &MemorySource{data: append([]byte{}, data...)}
+}
+
+// Set replaces the held configuration, to be picked up on the next reload
+func (s *MemorySource) Set(data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = append([]byte{}, data...)
+}
+
+func (s *MemorySource) Load() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]byte{}, s.data...), nil
+}
@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"atlas-family/invitation"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// InvitationExpiryJob periodically expires family invitations which were not answered in time
+type InvitationExpiryJob struct {
+	log      logrus.FieldLogger
+	db       *gorm.DB
+	interval time.Duration
+}
+
+// NewInvitationExpiryJob creates a new invitation expiry job from environment variables
+func NewInvitationExpiryJob(log logrus.FieldLogger, db *gorm.DB) *InvitationExpiryJob {
+	// Default to sweeping once a minute
+	interval := time.Minute
+
+	// Check for custom sweep interval
+	if intervalStr, ok := os.LookupEnv("INVITATION_SWEEP_INTERVAL_SECONDS"); ok {
+		if seconds, err := strconv.Atoi(intervalStr); err == nil && seconds > 0 {
+			interval = time.Duration(seconds) * time.Second
+		}
+	}
+
+	return &InvitationExpiryJob{
+		log:      log,
+		db:       db,
+		interval: interval,
+	}
+}
+
+// Start begins the invitation expiry sweep
+func (j *InvitationExpiryJob) Start(ctx context.Context) error {
+	j.log.WithFields(logrus.Fields{
+		"interval": j.interval.String(),
+	}).Info("Starting invitation expiry job scheduler")
+
+	go j.scheduleSweep(ctx)
+
+	return nil
+}
+
+// scheduleSweep runs the sweep on every tick until the context is cancelled
+func (j *InvitationExpiryJob) scheduleSweep(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.log.Info("Invitation expiry job scheduler stopped")
+			return
+		case <-ticker.C:
+			expired, err := invitation.ExpireDue(j.log, ctx, j.db)
+			if err != nil {
+				j.log.WithError(err).Error("Failed to execute invitation expiry job")
+				continue
+			}
+			if expired > 0 {
+				j.log.WithField("expired", expired).Info("Expired family invitations")
+			}
+		}
+	}
+}
+
+// Stop gracefully stops the invitation expiry job
+func (j *InvitationExpiryJob) Stop() {
+	j.log.Info("Stopping invitation expiry job")
+}
@@ -1,15 +1,17 @@
 package scheduler
 
 import (
-	"atlas-family/kafka/producer"
 	"context"
 	"os"
 	"strconv"
 	"time"
 
+	"atlas-family/buff"
 	"atlas-family/family"
 	"atlas-family/kafka/message"
+	"atlas-family/outbox"
 
+	tenant "github.com/Chronicle20/atlas-tenant"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
@@ -127,13 +129,39 @@ func (j *ReputationResetJob) executeResetJob(ctx context.Context) error {
 
 	startTime := time.Now()
 
-	// Execute the reset operation using the administrator
-	var result family.BatchResetResult
-	err := message.Emit(producer.ProviderImpl(j.log)(ctx))(func(buf *message.Buffer) error {
-		var err error
-		result, err = family.NewProcessor(j.log, ctx, j.db).ResetDailyRep(buf)()
+	tenantIds, err := family.GetTenantIdsProvider()(j.db)()
+	if err != nil {
 		return err
-	})
+	}
+
+	// Reset each tenant on its own so a failure in one does not hold back the others. Members only record their
+	// tenant ID, so each reset is announced under a tenant carrying that ID alone.
+	var affectedCount int64
+	resetTime := time.Now()
+	for _, tenantId := range tenantIds {
+		t, err := tenant.Create(tenantId, "", 0, 0)
+		if err != nil {
+			j.log.WithError(err).WithField("tenantId", tenantId).Error("Unable to create tenant for reputation reset")
+			continue
+		}
+		tctx := tenant.WithContext(ctx, t)
+
+		var result family.BatchResetResult
+		err = outbox.Emit(j.log, tctx, j.db)(func(tx *gorm.DB, buf *message.Buffer) error {
+			var err error
+			result, err = family.NewProcessor(j.log, tctx, tx).ResetDailyRep(buf)()
+			return err
+		})
+		if err != nil {
+			j.log.WithError(err).WithField("tenantId", tenantId).Error("Failed to reset daily reputation for tenant")
+			continue
+		}
+		affectedCount += result.AffectedCount
+		resetTime = result.ResetTime
+	}
+
+	// Reset daily buff redemption limits alongside daily reputation
+	buffsReset, err := buff.ResetDailyUsage(j.db, j.log)()
 	if err != nil {
 		return err
 	}
@@ -141,9 +169,11 @@ func (j *ReputationResetJob) executeResetJob(ctx context.Context) error {
 	duration := time.Since(startTime)
 
 	j.log.WithFields(logrus.Fields{
-		"affectedMembers": result.AffectedCount,
+		"tenants":         len(tenantIds),
+		"affectedMembers": affectedCount,
+		"buffUsageReset":  buffsReset,
 		"duration":        duration.String(),
-		"resetTime":       result.ResetTime.Format(time.RFC3339),
+		"resetTime":       resetTime.Format(time.RFC3339),
 	}).Info("Daily reputation reset completed successfully")
 
 	return nil
@@ -0,0 +1,37 @@
+package teleport
+
+import (
+	"os"
+	"strconv"
+)
+
+// EnvTeleportRepCost configures the rep charged for teleporting to a family member
+const EnvTeleportRepCost = "FAMILY_TELEPORT_REP_COST"
+
+// EnvSummonRepCost configures the rep charged for summoning a family member
+const EnvSummonRepCost = "FAMILY_SUMMON_REP_COST"
+
+// Default rep costs used when none are configured
+const (
+	DefaultTeleportRepCost = uint32(300)
+	DefaultSummonRepCost   = uint32(500)
+)
+
+// TeleportRepCost returns the configured teleport cost
+func TeleportRepCost() uint32 {
+	return repCost(EnvTeleportRepCost, DefaultTeleportRepCost)
+}
+
+// SummonRepCost returns the configured summon cost
+func SummonRepCost() uint32 {
+	return repCost(EnvSummonRepCost, DefaultSummonRepCost)
+}
+
+func repCost(env string, fallback uint32) uint32 {
+	if value, ok := os.LookupEnv(env); ok {
+		if cost, err := strconv.ParseUint(value, 10, 32); err == nil {
+			return uint32(cost)
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,65 @@
+package teleport
+
+import (
+	"atlas-family/family"
+)
+
+// Kinds of family warps
+const (
+	KindTeleport = "TELEPORT_TO_MEMBER"
+	KindSummon   = "SUMMON_MEMBER"
+)
+
+// Model represents an immutable record of a completed family warp
+type Model struct {
+	kind        string
+	characterId uint32
+	targetId    uint32
+	repCost     uint32
+	worldId     byte
+	mapId       uint32
+	member      family.FamilyMember
+}
+
+// Accessor methods for Model
+func (m Model) Kind() string {
+	return m.kind
+}
+
+func (m Model) CharacterId() uint32 {
+	return m.characterId
+}
+
+func (m Model) TargetId() uint32 {
+	return m.targetId
+}
+
+func (m Model) RepCost() uint32 {
+	return m.repCost
+}
+
+func (m Model) WorldId() byte {
+	return m.worldId
+}
+
+func (m Model) MapId() uint32 {
+	return m.mapId
+}
+
+// Member returns the caller after the rep cost was charged
+func (m Model) Member() family.FamilyMember {
+	return m.member
+}
+
+// IsRelated returns true if the target appears in the caller's family tree as someone other than the caller
+func IsRelated(tree []family.FamilyMember, characterId uint32, targetId uint32) bool {
+	if characterId == targetId {
+		return false
+	}
+	for _, m := range tree {
+		if m.CharacterId() == targetId {
+			return true
+		}
+	}
+	return false
+}
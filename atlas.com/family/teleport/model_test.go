@@ -0,0 +1,40 @@
+package teleport
+
+import (
+	"testing"
+
+	"atlas-family/family"
+
+	"github.com/google/uuid"
+)
+
+func TestIsRelated(t *testing.T) {
+	tenantId := uuid.New()
+	var tree []family.FamilyMember
+	for _, characterId := range []uint32{1000, 2000, 3000} {
+		m, err := family.NewBuilder(characterId, tenantId, 50, 1).Build()
+		if err != nil {
+			t.Fatalf("Failed to build family member: %v", err)
+		}
+		tree = append(tree, m)
+	}
+
+	tests := []struct {
+		name        string
+		characterId uint32
+		targetId    uint32
+		expected    bool
+	}{
+		{"Member of tree", 1000, 2000, true},
+		{"Outside tree", 1000, 4000, false},
+		{"Self", 1000, 1000, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsRelated(tree, tt.characterId, tt.targetId); result != tt.expected {
+				t.Errorf("IsRelated(%d, %d) = %v, want %v", tt.characterId, tt.targetId, result, tt.expected)
+			}
+		})
+	}
+}
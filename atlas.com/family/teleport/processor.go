@@ -0,0 +1,174 @@
+package teleport
+
+import (
+	"context"
+	"errors"
+
+	"atlas-family/family"
+	"atlas-family/idempotency"
+	"atlas-family/kafka/message"
+	familymsg "atlas-family/kafka/message/family"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Processor interface defines the family warp operations
+type Processor interface {
+	WithTransaction(db *gorm.DB) Processor
+	TeleportToMember(buf *message.Buffer) func(worldId byte, characterId uint32, targetId uint32, mapId uint32) model.Provider[Model]
+	SummonMember(buf *message.Buffer) func(worldId byte, characterId uint32, targetId uint32, mapId uint32) model.Provider[Model]
+
+	// AndEmit variants for Kafka message emission
+	TeleportToMemberAndEmit(transactionId uuid.UUID, worldId byte, characterId uint32, targetId uint32, mapId uint32) model.Provider[Model]
+	SummonMemberAndEmit(transactionId uuid.UUID, worldId byte, characterId uint32, targetId uint32, mapId uint32) model.Provider[Model]
+}
+
+// ProcessorImpl implements the Processor interface
+type ProcessorImpl struct {
+	log logrus.FieldLogger
+	ctx context.Context
+	db  *gorm.DB
+
+	// transactionId attributes the rep charged for a warp in the reputation ledger
+	transactionId uuid.UUID
+}
+
+// NewProcessor creates a new processor instance
+func NewProcessor(l logrus.FieldLogger, ctx context.Context, db *gorm.DB) Processor {
+	return &ProcessorImpl{
+		log: l,
+		ctx: ctx,
+		db:  db,
+	}
+}
+
+// Business logic errors
+var (
+	ErrTargetNotInFamily = errors.New("target is not a member of the caller's family")
+)
+
+func (p *ProcessorImpl) WithTransaction(db *gorm.DB) Processor {
+	return &ProcessorImpl{
+		log:           p.log,
+		ctx:           p.ctx,
+		db:            db,
+		transactionId: p.transactionId,
+	}
+}
+
+func (p *ProcessorImpl) withTransactionId(transactionId uuid.UUID) *ProcessorImpl {
+	tp := p.WithTransaction(p.db).(*ProcessorImpl)
+	tp.transactionId = transactionId
+	return tp
+}
+
+// TeleportToMember charges the caller and requests a warp of the caller to the target's map
+func (p *ProcessorImpl) TeleportToMember(buf *message.Buffer) func(worldId byte, characterId uint32, targetId uint32, mapId uint32) model.Provider[Model] {
+	return func(worldId byte, characterId uint32, targetId uint32, mapId uint32) model.Provider[Model] {
+		return p.warp(buf, KindTeleport, familymsg.EventTypeTeleportUsed, TeleportRepCost(), worldId, characterId, targetId, mapId)
+	}
+}
+
+// SummonMember charges the caller and requests a warp of the target to the caller's map
+func (p *ProcessorImpl) SummonMember(buf *message.Buffer) func(worldId byte, characterId uint32, targetId uint32, mapId uint32) model.Provider[Model] {
+	return func(worldId byte, characterId uint32, targetId uint32, mapId uint32) model.Provider[Model] {
+		return p.warp(buf, KindSummon, familymsg.EventTypeSummonUsed, SummonRepCost(), worldId, characterId, targetId, mapId)
+	}
+}
+
+// warp validates the target is related to the caller, deducts the cost and buffers the warp event
+func (p *ProcessorImpl) warp(buf *message.Buffer, kind string, eventType string, repCost uint32, worldId byte, characterId uint32, targetId uint32, mapId uint32) model.Provider[Model] {
+	return func() (Model, error) {
+		p.log.WithFields(logrus.Fields{
+			"kind":        kind,
+			"characterId": characterId,
+			"targetId":    targetId,
+			"mapId":       mapId,
+		}).Info("Using family warp")
+
+		var result Model
+		err := p.db.Transaction(func(tx *gorm.DB) error {
+			fp := family.NewProcessor(p.log, p.ctx, tx).WithTransactionId(p.transactionId)
+
+			tree, err := fp.GetFamilyTree(characterId)
+			if err != nil {
+				return err
+			}
+			if !IsRelated(tree, characterId, targetId) {
+				return ErrTargetNotInFamily
+			}
+
+			for _, m := range tree {
+				if m.CharacterId() == characterId && m.Rep() < repCost {
+					return family.ErrInsufficientRep
+				}
+			}
+
+			member, err := fp.DeductRep(buf)(characterId, repCost, kind)()
+			if err != nil {
+				return err
+			}
+
+			result = Model{
+				kind:        kind,
+				characterId: characterId,
+				targetId:    targetId,
+				repCost:     repCost,
+				worldId:     worldId,
+				mapId:       mapId,
+				member:      member,
+			}
+			return nil
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrTargetNotInFamily):
+				p.putError(buf, worldId, characterId, "TARGET_NOT_IN_FAMILY", err, repCost)
+			case errors.Is(err, family.ErrInsufficientRep):
+				p.putError(buf, worldId, characterId, "INSUFFICIENT_REP", err, repCost)
+			case errors.Is(err, family.ErrMemberNotFound):
+				p.putError(buf, worldId, characterId, "MEMBER_NOT_FOUND", err, repCost)
+			}
+			return Model{}, err
+		}
+
+		if buf != nil {
+			if putErr := buf.Put(familymsg.EnvEventTopicRep, UsedEventProvider(eventType, result)); putErr != nil {
+				p.log.WithError(putErr).Error("Failed to add teleport used event to buffer")
+			}
+		}
+		return result, nil
+	}
+}
+
+func (p *ProcessorImpl) putError(buf *message.Buffer, worldId byte, characterId uint32, errorCode string, err error, amount uint32) {
+	if buf == nil {
+		return
+	}
+	if putErr := buf.Put(familymsg.EnvEventTopicErrors, family.RepErrorEventProvider(worldId, characterId, errorCode, err.Error(), amount)); putErr != nil {
+		p.log.WithError(putErr).Error("Failed to add rep error event to buffer")
+	}
+}
+
+// AndEmit variants - combine business logic with event emission. Each runs at most once per transaction ID.
+
+// TeleportToMemberAndEmit teleports to a family member and emits appropriate events
+func (p *ProcessorImpl) TeleportToMemberAndEmit(transactionId uuid.UUID, worldId byte, characterId uint32, targetId uint32, mapId uint32) model.Provider[Model] {
+	return func() (Model, error) {
+		return idempotency.EmitOnce[Model](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeTeleportToMember)(func(tx *gorm.DB, buf *message.Buffer) (Model, error) {
+			return p.WithTransaction(tx).(*ProcessorImpl).withTransactionId(transactionId).TeleportToMember(buf)(worldId, characterId, targetId, mapId)()
+		})
+	}
+}
+
+// SummonMemberAndEmit summons a family member and emits appropriate events
+func (p *ProcessorImpl) SummonMemberAndEmit(transactionId uuid.UUID, worldId byte, characterId uint32, targetId uint32, mapId uint32) model.Provider[Model] {
+	return func() (Model, error) {
+		return idempotency.EmitOnce[Model](p.log, p.ctx, p.db)(transactionId, familymsg.CommandTypeSummonMember)(func(tx *gorm.DB, buf *message.Buffer) (Model, error) {
+			return p.WithTransaction(tx).(*ProcessorImpl).withTransactionId(transactionId).SummonMember(buf)(worldId, characterId, targetId, mapId)()
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package teleport
+
+import (
+	"atlas-family/kafka/message/family"
+
+	"github.com/Chronicle20/atlas-kafka/producer"
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/segmentio/kafka-go"
+)
+
+// UsedEventProvider creates a Kafka message provider instructing the channel service to perform a family warp
+func UsedEventProvider(eventType string, m Model) model.Provider[[]kafka.Message] {
+	key := producer.CreateKey(int(m.CharacterId()))
+	value := family.NewTeleportUsedEvent(eventType, m.WorldId(), m.CharacterId(), m.TargetId(), m.RepCost(), m.MapId())
+	return producer.SingleMessageProvider(key, value)
+}
@@ -0,0 +1,31 @@
+package textfilter
+
+import (
+	"os"
+	"sync"
+)
+
+// EnvWordListFile configures the path of the word list used to moderate family text
+const EnvWordListFile = "FAMILY_TEXT_FILTER_FILE"
+
+var (
+	defaultOnce   sync.Once
+	defaultFilter Filter
+)
+
+// Default returns the configured filter, loading the word list from EnvWordListFile on first use.
+// Without a configured word list every text is allowed.
+func Default() Filter {
+	defaultOnce.Do(func() {
+		defaultFilter = NewWordList()
+		if path, ok := os.LookupEnv(EnvWordListFile); ok {
+			if f, err := os.Open(path); err == nil {
+				defer f.Close()
+				if w, err := ReadWordList(f); err == nil {
+					defaultFilter = w
+				}
+			}
+		}
+	})
+	return defaultFilter
+}
@@ -0,0 +1,61 @@
+package textfilter
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrTextRejected is returned when a filter refuses a piece of player supplied text
+var ErrTextRejected = errors.New("text contains disallowed content")
+
+// Filter moderates player supplied text before it is stored and shown to other players
+type Filter interface {
+	Check(text string) error
+}
+
+// WordList rejects text containing any of its words, ignoring case
+type WordList struct {
+	words []string
+}
+
+// NewWordList creates a word list filter, skipping blank entries
+func NewWordList(words ...string) WordList {
+	normalized := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word != "" {
+			normalized = append(normalized, word)
+		}
+	}
+	return WordList{words: normalized}
+}
+
+// ReadWordList reads one word per line, ignoring blank lines and lines starting with '#'
+func ReadWordList(r io.Reader) (WordList, error) {
+	words := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return WordList{}, err
+	}
+	return NewWordList(words...), nil
+}
+
+// Check returns ErrTextRejected if the text contains a listed word
+func (w WordList) Check(text string) error {
+	lowered := strings.ToLower(text)
+	for _, word := range w.words {
+		if strings.Contains(lowered, word) {
+			return ErrTextRejected
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package textfilter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWordList_Check(t *testing.T) {
+	w := NewWordList("darn", "  ", "Heck")
+
+	tests := []struct {
+		name string
+		text string
+		want error
+	}{
+		{"clean text", "Work hard, play fair", nil},
+		{"empty text", "", nil},
+		{"listed word", "well darn it", ErrTextRejected},
+		{"case insensitive", "HECK yes", ErrTextRejected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := w.Check(tt.text); !errors.Is(err, tt.want) {
+				t.Errorf("Check(%q) = %v, want %v", tt.text, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadWordList(t *testing.T) {
+	w, err := ReadWordList(strings.NewReader("# comment\ndarn\n\nheck\n"))
+	if err != nil {
+		t.Fatalf("Failed to read word list: %v", err)
+	}
+	if len(w.words) != 2 {
+		t.Fatalf("Expected 2 words, got %v", w.words)
+	}
+	if err := w.Check("# comment"); err != nil {
+		t.Errorf("Expected comments to be skipped, got %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package tombstone
+
+import (
+	"time"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Record marks a character as deleted. It returns false if the character was already recorded.
+func Record(db *gorm.DB, log logrus.FieldLogger) func(tenantId uuid.UUID, characterId uint32) model.Provider[bool] {
+	return func(tenantId uuid.UUID, characterId uint32) model.Provider[bool] {
+		return func() (bool, error) {
+			log.WithField("characterId", characterId).Debug("Recording character tombstone")
+
+			entity := Entity{
+				TenantId:    tenantId,
+				CharacterId: characterId,
+				DeletedAt:   time.Now(),
+			}
+			result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity)
+			if result.Error != nil {
+				return false, result.Error
+			}
+			return result.RowsAffected > 0, nil
+		}
+	}
+}
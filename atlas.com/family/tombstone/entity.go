@@ -0,0 +1,25 @@
+package tombstone
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Entity records a deleted character so that commands arriving after the deletion can be rejected
+type Entity struct {
+	TenantId    uuid.UUID `gorm:"type:uuid;primaryKey" json:"tenantId"`
+	CharacterId uint32    `gorm:"primaryKey" json:"characterId"`
+	DeletedAt   time.Time `gorm:"not null" json:"deletedAt"`
+}
+
+// TableName specifies the table name for the Entity
+func (Entity) TableName() string {
+	return "family_tombstones"
+}
+
+// Migration creates the family_tombstones table
+func Migration(db *gorm.DB) error {
+	return db.AutoMigrate(&Entity{})
+}
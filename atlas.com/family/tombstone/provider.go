@@ -0,0 +1,22 @@
+package tombstone
+
+import (
+	"atlas-family/database"
+
+	"github.com/Chronicle20/atlas-model/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExistsProvider returns a provider reporting whether the character has been deleted
+func ExistsProvider(tenantId uuid.UUID, characterId uint32) database.EntityProvider[bool] {
+	return func(db *gorm.DB) model.Provider[bool] {
+		return func() (bool, error) {
+			var count int64
+			if err := db.Model(&Entity{}).Where("tenant_id = ? AND character_id = ?", tenantId, characterId).Count(&count).Error; err != nil {
+				return false, err
+			}
+			return count > 0, nil
+		}
+	}
+}